@@ -1,8 +1,7 @@
 package sb
 
 import (
-	"errors"
-	"fmt"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -25,43 +24,80 @@ type Column struct {
 	Nullable      bool
 	Unique        bool
 	Default       string
+
+	// DefaultExpression makes the Default an SQL expression, i.e.
+	// CURRENT_TIMESTAMP, 0 or datetime('now'), instead of a string literal
+	DefaultExpression bool
+
+	// References is the foreign key of the column, its Columns are
+	// the column itself, i.e. &ForeignKey{ReferencedTable: "users",
+	// ReferencedColumns: []string{"id"}, OnDelete: FOREIGN_KEY_ACTION_CASCADE}
+	References *ForeignKey
 }
 
 type GroupBy struct {
 	Column string
 }
 
+// TruncateOptions are the options of the Truncate statement
+type TruncateOptions struct {
+	// RestartIdentity resets the auto increment counters of the table.
+	// MySQL and MSSQL always reset them
+	RestartIdentity bool
+
+	// Cascade truncates the tables referencing the table with
+	// foreign keys as well (PostgreSQL only)
+	Cascade bool
+}
+
 type Builder struct {
-	Dialect            string
-	sql                map[string]any
-	sqlColumns         []Column
-	sqlGroupBy         []GroupBy
-	sqlLimit           int64
-	sqlOffset          int64
-	sqlOrderBy         []OrderBy
-	sqlTableName       string
-	sqlViewName        string
-	sqlViewColumns     []string
-	sqlViewSQL         string
-	sqlWhere           []Where
-	columnSQLGenerator ColumnSQLGenerator
+	Dialect              string
+	sql                  map[string]any
+	sqlAllowedColumns    []string
+	sqlColumns           []Column
+	sqlCompound          []compoundQuery
+	sqlDistinct          bool
+	sqlDistinctOn        []string
+	sqlForeignKeys       []ForeignKey
+	sqlFromSubquery      *Subquery
+	sqlGroupBy           []GroupBy
+	sqlHaving            []Where
+	sqlJoin              []Join
+	sqlLimit             int64
+	sqlLock              *Lock
+	sqlOffset            int64
+	sqlOrderBy           []OrderBy
+	sqlParams            []any
+	sqlParamsEnabled     bool
+	sqlReturning         []string
+	sqlSelectColumns     []string
+	sqlSelectExpressions []Expression
+	sqlSelectSubqueries  []Subquery
+	sqlTableName         string
+	sqlViewName          string
+	sqlViewColumns       []string
+	sqlViewSQL           string
+	sqlWhere             []Where
+	sqlWith              []commonTableExpression
+	columnSQLGenerator   ColumnSQLGenerator
+	dialect              Dialect
+	immutable            bool
+	version              string
+	err                  error
 }
 
 var _ BuilderInterface = (*Builder)(nil)
 
 func NewBuilder(dialect string) *Builder {
 	var columnSQLGenerator ColumnSQLGenerator
-	switch dialect {
-	case DIALECT_MYSQL:
-		columnSQLGenerator = MySQLColumnSQLGenerator{}
-	case DIALECT_POSTGRES:
-		columnSQLGenerator = PostgreSQLColumnSQLGenerator{}
-	case DIALECT_SQLITE:
-		columnSQLGenerator = SQLiteColumnSQLGenerator{}
-	case DIALECT_MSSQL:
-		columnSQLGenerator = MSSQLColumnSQLGenerator{}
-	default:
-		panic("unsupported dialect: " + dialect)
+	var err error
+
+	registered, ok := LookupDialect(dialect)
+	if ok {
+		columnSQLGenerator = registered.ColumnSQLGenerator()
+	} else {
+		// Reported by the methods converting the query to SQL
+		err = errUnsupportedDialect(dialect)
 	}
 
 	return &Builder{
@@ -69,6 +105,8 @@ func NewBuilder(dialect string) *Builder {
 		sql:                map[string]any{},
 		sqlColumns:         []Column{},
 		sqlGroupBy:         []GroupBy{},
+		sqlHaving:          []Where{},
+		sqlJoin:            []Join{},
 		sqlLimit:           0,
 		sqlOffset:          0,
 		sqlOrderBy:         []OrderBy{},
@@ -78,38 +116,51 @@ func NewBuilder(dialect string) *Builder {
 		sqlViewSQL:         "",
 		sqlWhere:           []Where{},
 		columnSQLGenerator: columnSQLGenerator,
+		dialect:            registered,
+		err:                err,
 	}
 }
 
 func (b *Builder) Table(tableName string) BuilderInterface {
+	b = b.mutable()
 	b.sqlTableName = tableName
 	return b
 }
 
 func (b *Builder) View(viewName string) BuilderInterface {
+	b = b.mutable()
 	b.sqlViewName = viewName
 	return b
 }
 
 func (b *Builder) ViewSQL(sql string) BuilderInterface {
+	b = b.mutable()
 	b.sqlViewSQL = sql
 	return b
 }
 
 func (b *Builder) ViewColumns(columns []string) BuilderInterface {
+	b = b.mutable()
 	b.sqlViewColumns = columns
 	return b
 }
 
 func (b *Builder) Column(column Column) BuilderInterface {
 	if column.Name == "" {
-		panic("column name is required")
+		return b.errorAdd(errInvalidQuery("column name is required"))
 	}
 
 	if column.Type == "" {
-		panic("column type is required")
+		return b.errorAdd(errInvalidQuery("column type is required"))
+	}
+
+	if column.References != nil {
+		if err := b.validateForeignKey(columnForeignKey(column)); err != nil {
+			return b.errorAdd(err)
+		}
 	}
 
+	b = b.mutable()
 	b.sqlColumns = append(b.sqlColumns, column)
 
 	return b
@@ -132,22 +183,28 @@ func (b *Builder) Column(column Column) BuilderInterface {
  * @access public
  */
 func (b *Builder) Create() string {
+	b.failOnError()
+
 	isView := b.sqlViewName != ""
 	isTable := b.sqlTableName != ""
 
+	if !isView && !isTable {
+		b.fail(errNoTable("Create"))
+	}
+
 	sql := ""
 
 	if isTable {
-		if b.Dialect == DIALECT_MYSQL || b.Dialect == DIALECT_POSTGRES || b.Dialect == DIALECT_SQLITE {
-			sql = `CREATE TABLE ` + b.quoteTable(b.sqlTableName) + `(` + b.columnsToSQL(b.sqlColumns) + `);`
+		if b.syntax() == DIALECT_MYSQL || b.syntax() == DIALECT_POSTGRES || b.syntax() == DIALECT_SQLITE {
+			sql = `CREATE TABLE ` + b.quoteTable(b.sqlTableName) + `(` + b.tableDefinitionToSql() + `);`
 		}
-		if b.Dialect == DIALECT_MSSQL {
-			sql = `CREATE TABLE [` + b.sqlTableName + `] (` + b.columnsToSQL(b.sqlColumns) + `);`
+		if b.syntax() == DIALECT_MSSQL {
+			sql = `CREATE TABLE ` + b.quoteTable(b.sqlTableName) + ` (` + b.tableDefinitionToSql() + `);`
 		}
 	}
 
 	if isView {
-		if b.Dialect == DIALECT_MYSQL || b.Dialect == DIALECT_POSTGRES || b.Dialect == DIALECT_SQLITE {
+		if b.syntax() == DIALECT_MYSQL || b.syntax() == DIALECT_POSTGRES || b.syntax() == DIALECT_SQLITE || b.syntax() == DIALECT_MSSQL {
 			viewColumnsToSQL := strings.Join(lo.Map(b.sqlViewColumns, func(columnName string, _ int) string {
 				return b.quoteColumn(columnName)
 			}), ", ")
@@ -161,45 +218,73 @@ func (b *Builder) Create() string {
 }
 
 func (b *Builder) CreateIfNotExists() string {
+	b.failOnError()
+
 	isView := b.sqlViewName != ""
 	isTable := b.sqlTableName != ""
 
+	if !isView && !isTable {
+		b.fail(errNoTable("CreateIfNotExists"))
+	}
+
+	if !b.supports(FEATURE_IF_NOT_EXISTS) {
+		b.fail(b.errUnsupportedFeature("if not exists"))
+	}
+
 	sql := ""
 
 	if isTable {
-		if b.Dialect == DIALECT_MYSQL {
-			sql = "CREATE TABLE IF NOT EXISTS " + b.quoteTable(b.sqlTableName) + "(" + b.columnsToSQL(b.sqlColumns) + ");"
+		if b.syntax() == DIALECT_MYSQL {
+			sql = "CREATE TABLE IF NOT EXISTS " + b.quoteTable(b.sqlTableName) + "(" + b.tableDefinitionToSql() + ");"
 		}
-		if b.Dialect == DIALECT_POSTGRES {
-			sql = `CREATE TABLE IF NOT EXISTS ` + b.quoteTable(b.sqlTableName) + `(` + b.columnsToSQL(b.sqlColumns) + `);`
+		if b.syntax() == DIALECT_POSTGRES {
+			sql = `CREATE TABLE IF NOT EXISTS ` + b.quoteTable(b.sqlTableName) + `(` + b.tableDefinitionToSql() + `);`
 		}
-		if b.Dialect == DIALECT_SQLITE {
-			sql = "CREATE TABLE IF NOT EXISTS " + b.quoteTable(b.sqlTableName) + "(" + b.columnsToSQL(b.sqlColumns) + ");"
+		if b.syntax() == DIALECT_SQLITE {
+			sql = "CREATE TABLE IF NOT EXISTS " + b.quoteTable(b.sqlTableName) + "(" + b.tableDefinitionToSql() + ");"
+		}
+		if b.syntax() == DIALECT_MSSQL {
+			sql = "IF " + b.mssqlObjectIdToSql(b.sqlTableName, mssqlObjectTypeTable) + " IS NULL CREATE TABLE " + b.quoteTable(b.sqlTableName) + " (" + b.tableDefinitionToSql() + ");"
 		}
 	}
 
 	if isView {
-		if b.Dialect == DIALECT_MYSQL || b.Dialect == DIALECT_POSTGRES || b.Dialect == DIALECT_SQLITE {
+		if b.syntax() == DIALECT_MYSQL || b.syntax() == DIALECT_POSTGRES || b.syntax() == DIALECT_SQLITE {
 			viewColumnsToSQL := strings.Join(lo.Map(b.sqlViewColumns, func(columnName string, _ int) string {
 				return b.quoteColumn(columnName)
 			}), ", ")
 			viewColumns := lo.If(len(b.sqlViewColumns) > 0, ` (`+viewColumnsToSQL+`)`).Else(``)
 
 			sqlStart := "CREATE VIEW IF NOT EXISTS"
-			if b.Dialect == DIALECT_MYSQL {
+			if b.syntax() == DIALECT_MYSQL {
 				sqlStart = "CREATE OR REPLACE VIEW"
 			}
 
 			sql = sqlStart + ` ` + b.quoteTable(b.sqlViewName) + viewColumns + " AS " + b.sqlViewSQL
 		}
+
+		// CREATE VIEW must be the only statement in a MSSQL batch,
+		// so behind the guard it is executed as a string
+		if b.syntax() == DIALECT_MSSQL {
+			viewColumnsToSQL := strings.Join(lo.Map(b.sqlViewColumns, func(columnName string, _ int) string {
+				return b.quoteColumn(columnName)
+			}), ", ")
+			viewColumns := lo.If(len(b.sqlViewColumns) > 0, ` (`+viewColumnsToSQL+`)`).Else(``)
+
+			createView := `CREATE VIEW ` + b.quoteTable(b.sqlViewName) + viewColumns + " AS " + b.sqlViewSQL
+
+			sql = "IF " + b.mssqlObjectIdToSql(b.sqlViewName, mssqlObjectTypeView) + " IS NULL EXEC(" + b.mssqlStringToSql(createView) + ");"
+		}
 	}
 
 	return sql
 }
 
 func (b *Builder) CreateIndex(indexName string, columnName ...string) string {
+	b.failOnError()
+
 	if b.sqlTableName == "" {
-		panic("In method CreateIndex() no table specified to create index on!")
+		b.fail(errNoTable("CreateIndex"))
 	}
 
 	columns := lo.Map(columnName, func(columnName string, i int) string {
@@ -223,8 +308,19 @@ func (b *Builder) CreateIndex(indexName string, columnName ...string) string {
  */
 // Drop deletes a table
 func (b *Builder) Delete() string {
+	b.failOnError()
+
 	if b.sqlTableName == "" {
-		panic("In method Delete() no table specified to delete from!")
+		b.fail(errNoTable("Delete"))
+	}
+
+	with := ""
+	if len(b.sqlWith) > 0 {
+		with = b.withToSql(b.sqlWith)
+	}
+
+	if len(b.sqlJoin) > 0 {
+		return with + b.deleteJoinToSql()
 	}
 
 	where := ""
@@ -248,27 +344,36 @@ func (b *Builder) Delete() string {
 	}
 
 	sql := ""
-	if b.Dialect == DIALECT_MYSQL || b.Dialect == DIALECT_POSTGRES || b.Dialect == DIALECT_SQLITE {
-		sql = "DELETE FROM " + b.quoteTable(b.sqlTableName) + where + orderBy + limit + offset + ";"
+	if b.syntax() == DIALECT_MYSQL || b.syntax() == DIALECT_POSTGRES || b.syntax() == DIALECT_SQLITE {
+		sql = with + "DELETE FROM " + b.quoteTable(b.sqlTableName) + where + b.returningToSql() + orderBy + limit + offset + ";"
+	}
+	if b.syntax() == DIALECT_MSSQL {
+		sql = with + "DELETE" + b.mssqlTopToSql("Delete") + " FROM " + b.quoteTable(b.sqlTableName) + b.outputToSql("DELETED") + where + ";"
 	}
 	return sql
 }
 
 // Drop deletes a table or a view
 func (b *Builder) Drop() string {
+	b.failOnError()
+
 	isView := b.sqlViewName != ""
 	isTable := b.sqlTableName != ""
 
+	if !isView && !isTable {
+		b.fail(errNoTable("Drop"))
+	}
+
 	sql := ""
 
 	if isTable {
-		if b.Dialect == DIALECT_MYSQL || b.Dialect == DIALECT_POSTGRES || b.Dialect == DIALECT_SQLITE {
+		if b.syntax() == DIALECT_MYSQL || b.syntax() == DIALECT_POSTGRES || b.syntax() == DIALECT_SQLITE || b.syntax() == DIALECT_MSSQL {
 			sql = "DROP TABLE " + b.quoteTable(b.sqlTableName) + ";"
 		}
 	}
 
 	if isView {
-		if b.Dialect == DIALECT_MYSQL || b.Dialect == DIALECT_POSTGRES || b.Dialect == DIALECT_SQLITE {
+		if b.syntax() == DIALECT_MYSQL || b.syntax() == DIALECT_POSTGRES || b.syntax() == DIALECT_SQLITE || b.syntax() == DIALECT_MSSQL {
 			sql = "DROP VIEW " + b.quoteTable(b.sqlViewName) + ";"
 		}
 	}
@@ -277,37 +382,52 @@ func (b *Builder) Drop() string {
 }
 
 func (b *Builder) DropIfExists() string {
+	b.failOnError()
+
 	isView := b.sqlViewName != ""
 	isTable := b.sqlTableName != ""
 
+	if !isView && !isTable {
+		b.fail(errNoTable("DropIfExists"))
+	}
+
 	sql := ""
 
 	if isTable {
-		if b.Dialect == DIALECT_MYSQL || b.Dialect == DIALECT_POSTGRES || b.Dialect == DIALECT_SQLITE {
+		if b.syntax() == DIALECT_MYSQL || b.syntax() == DIALECT_POSTGRES || b.syntax() == DIALECT_SQLITE {
 			sql = "DROP TABLE IF EXISTS " + b.quoteTable(b.sqlTableName) + ";"
 		}
+		if b.syntax() == DIALECT_MSSQL {
+			sql = "IF " + b.mssqlObjectIdToSql(b.sqlTableName, mssqlObjectTypeTable) + " IS NOT NULL DROP TABLE " + b.quoteTable(b.sqlTableName) + ";"
+		}
 	}
 
 	if isView {
-		if b.Dialect == DIALECT_MYSQL || b.Dialect == DIALECT_POSTGRES || b.Dialect == DIALECT_SQLITE {
+		if b.syntax() == DIALECT_MYSQL || b.syntax() == DIALECT_POSTGRES || b.syntax() == DIALECT_SQLITE {
 			sql = "DROP VIEW IF EXISTS " + b.quoteTable(b.sqlViewName) + ";"
 		}
+		if b.syntax() == DIALECT_MSSQL {
+			sql = "IF " + b.mssqlObjectIdToSql(b.sqlViewName, mssqlObjectTypeView) + " IS NOT NULL DROP VIEW " + b.quoteTable(b.sqlViewName) + ";"
+		}
 	}
 
 	return sql
 }
 
 func (b *Builder) Limit(limit int64) BuilderInterface {
+	b = b.mutable()
 	b.sqlLimit = limit
 	return b
 }
 
 func (b *Builder) Offset(offset int64) BuilderInterface {
+	b = b.mutable()
 	b.sqlOffset = offset
 	return b
 }
 
 func (b *Builder) GroupBy(groupBy GroupBy) BuilderInterface {
+	b = b.mutable()
 	b.sqlGroupBy = append(b.sqlGroupBy, groupBy)
 	return b
 }
@@ -319,6 +439,7 @@ func (b *Builder) OrderBy(columnName, direction string) BuilderInterface {
 		direction = "ASC"
 	}
 
+	b = b.mutable()
 	b.sqlOrderBy = append(b.sqlOrderBy, OrderBy{
 		Column:    columnName,
 		Direction: direction,
@@ -329,102 +450,184 @@ func (b *Builder) OrderBy(columnName, direction string) BuilderInterface {
 
 // Rename renames a table or a view
 func (b *Builder) TableRename(oldTableName, newTableName string) (sql string, err error) {
-	if b.Dialect == DIALECT_MSSQL {
-		sql = "EXEC sp_rename " + b.quoteTable(oldTableName) + ", " + b.quoteTable(newTableName) + ", 'OBJECT';"
+	defer recoverError(&err)
+
+	if b.err != nil {
+		return "", b.err
+	}
+
+	if b.syntax() == DIALECT_MSSQL {
+		// The new name is taken literally by sp_rename, so it is not quoted
+		b.validateIdentifier(newTableName)
+		sql = "EXEC sp_rename " + b.mssqlStringToSql(b.quoteTable(oldTableName)) + ", " + b.mssqlStringToSql(newTableName) + ", 'OBJECT';"
 		return sql, nil
 	}
 
-	if b.Dialect == DIALECT_SQLITE {
+	if b.syntax() == DIALECT_SQLITE {
 		sql = "ALTER TABLE " + b.quoteTable(oldTableName) + " RENAME TO " + b.quoteTable(newTableName) + ";"
 		return sql, nil
 	}
 
-	if b.Dialect == DIALECT_MYSQL {
+	if b.syntax() == DIALECT_MYSQL {
 		sql = "ALTER TABLE " + b.quoteTable(oldTableName) + " RENAME " + b.quoteTable(newTableName) + ";"
 		return sql, nil
 	}
 
-	if b.Dialect == DIALECT_POSTGRES {
+	if b.syntax() == DIALECT_POSTGRES {
 		sql = "ALTER TABLE " + b.quoteTable(oldTableName) + " RENAME TO " + b.quoteTable(newTableName) + ";"
 		return sql, nil
 	}
 
-	return "", errors.New("renaming a table is not supported for driver " + b.Dialect + "")
+	return "", b.errUnsupportedFeature("renaming a table")
 }
 
 // TableColumnAdd adds a column to the table
 func (b *Builder) TableColumnAdd(tableName string, column Column) (sql string, err error) {
-	if b.Dialect == DIALECT_MSSQL {
-		sql = "ALTER TABLE " + b.quoteTable(tableName) + " ADD " + b.columnsToSQL([]Column{column}) + ";"
+	defer recoverError(&err)
+
+	if b.err != nil {
+		return "", b.err
+	}
+
+	if column.References != nil {
+		if err := b.validateForeignKey(columnForeignKey(column)); err != nil {
+			return "", err
+		}
+	}
+
+	if b.syntax() == DIALECT_MSSQL {
+		sql = "ALTER TABLE " + b.quoteTable(tableName) + " ADD " + b.columnsToSQL([]Column{column}) + b.columnReferencesToSql(column) + ";"
 		return sql, nil
 	}
 
-	if b.Dialect == DIALECT_SQLITE {
-		sql = "ALTER TABLE " + b.quoteTable(tableName) + " ADD COLUMN " + b.columnsToSQL([]Column{column}) + ";"
+	if b.syntax() == DIALECT_SQLITE {
+		sql = "ALTER TABLE " + b.quoteTable(tableName) + " ADD COLUMN " + b.columnsToSQL([]Column{column}) + b.columnReferencesToSql(column) + ";"
 		return sql, nil
 	}
 
-	if b.Dialect == DIALECT_MYSQL {
-		sql = "ALTER TABLE " + b.quoteTable(tableName) + " ADD " + b.columnsToSQL([]Column{column}) + ";"
+	if b.syntax() == DIALECT_MYSQL {
+		sql = "ALTER TABLE " + b.quoteTable(tableName) + " ADD " + b.columnsToSQL([]Column{column}) + b.columnReferencesToSql(column) + ";"
 		return sql, nil
 	}
 
-	if b.Dialect == DIALECT_POSTGRES {
-		sql = "ALTER TABLE " + b.quoteTable(tableName) + " ADD " + b.columnsToSQL([]Column{column}) + ";"
+	if b.syntax() == DIALECT_POSTGRES {
+		sql = "ALTER TABLE " + b.quoteTable(tableName) + " ADD " + b.columnsToSQL([]Column{column}) + b.columnReferencesToSql(column) + ";"
 		return sql, nil
 	}
 
-	return "", errors.New("adding a column is not supported for driver " + b.Dialect + "")
+	return "", b.errUnsupportedFeature("adding a column")
 }
 
-// TableColumnChange changes a column in the table
+// TableColumnChange changes a column in the table. PostgreSQL changes the
+// type, the nullability and the default of the column, and adds the primary
+// key and the unique constraints. SQLite can not alter a column, so there
+// it returns ErrUnsupportedFeature
 func (b *Builder) TableColumnChange(tableName string, column Column) (sqlString string, err error) {
-	if b.Dialect == DIALECT_MSSQL {
-		sqlString = "ALTER TABLE " + b.quoteTable(tableName) + " ALTER COLUMN " + b.columnsToSQL([]Column{column}) + ";"
-		return sqlString, nil
+	defer recoverError(&err)
+
+	if b.err != nil {
+		return "", b.err
 	}
 
-	if b.Dialect == DIALECT_SQLITE {
-		sqlString = "ALTER TABLE " + b.quoteTable(tableName) + " ALTER COLUMN " + b.columnsToSQL([]Column{column}) + ";"
+	if b.syntax() == DIALECT_MSSQL {
+		// ALTER COLUMN can not set the default, it is added as a constraint
+		columnWithoutDefault := column
+		columnWithoutDefault.Default = ""
+
+		sqlString = "ALTER TABLE " + b.quoteTable(tableName) + " ALTER COLUMN " + b.columnsToSQL([]Column{columnWithoutDefault}) + ";"
+
+		if column.Default != "" {
+			sqlString += " ALTER TABLE " + b.quoteTable(tableName) + " ADD" + columnDefaultToSql(column, MSSQLDialect{}.QuoteString) + " FOR " + b.quoteColumn(column.Name) + ";"
+		}
+
 		return sqlString, nil
 	}
 
-	if b.Dialect == DIALECT_MYSQL {
+	if b.syntax() == DIALECT_MYSQL {
 		sqlString = "ALTER TABLE " + b.quoteTable(tableName) + " MODIFY COLUMN " + b.columnsToSQL([]Column{column}) + ";"
 		return sqlString, nil
 	}
 
-	if b.Dialect == DIALECT_POSTGRES {
-		sqlString = "ALTER TABLE " + b.quoteTable(tableName) + " ALTER COLUMN " + b.columnsToSQL([]Column{column}) + ";"
-		return sqlString, nil
+	if b.syntax() == DIALECT_POSTGRES {
+		return "ALTER TABLE " + b.quoteTable(tableName) + " " + b.postgresColumnChangeToSql(column) + ";", nil
+	}
+
+	if b.syntax() == DIALECT_SQLITE {
+		// SQLite can not alter a column, the table must be rebuilt
+		return "", b.errUnsupportedFeature("changing a column")
+	}
+
+	return "", b.errUnsupportedFeature("modifying a column")
+}
+
+// postgresColumnChangeToSql converts the column to the PostgreSQL
+// ALTER COLUMN actions, which change the type, the nullability
+// and the default separately. The auto increment (a sequence)
+// can not be added to an existing column
+func (b *Builder) postgresColumnChangeToSql(column Column) string {
+	if column.AutoIncrement {
+		b.fail(b.errUnsupportedFeature("in method TableColumnChange() auto increment"))
+	}
+
+	b.validateIdentifier(column.Name)
+
+	alterColumn := "ALTER COLUMN " + b.quoteColumn(column.Name)
+
+	actions := []string{
+		alterColumn + " TYPE " + postgresColumnTypeToSql(column),
+		alterColumn + lo.Ternary(column.Nullable, " DROP NOT NULL", " SET NOT NULL"),
+	}
+
+	if column.Default == "" {
+		actions = append(actions, alterColumn+" DROP DEFAULT")
+	} else {
+		actions = append(actions, alterColumn+" SET"+columnDefaultToSql(column, PostgresDialect{}.QuoteString))
+	}
+
+	if column.PrimaryKey {
+		actions = append(actions, "ADD PRIMARY KEY ("+b.quoteColumn(column.Name)+")")
 	}
 
-	return "", errors.New("modifying a column is not supported for driver " + b.Dialect + "")
+	if column.Unique {
+		actions = append(actions, "ADD UNIQUE ("+b.quoteColumn(column.Name)+")")
+	}
+
+	return strings.Join(actions, ", ")
 }
 
 // TableColumnDrop drops a column from the table
 func (b *Builder) TableColumnDrop(tableName string, columnName string) (sqlString string, err error) {
-	if b.Dialect == DIALECT_MSSQL {
+	defer recoverError(&err)
+
+	if b.err != nil {
+		return "", b.err
+	}
+
+	if !b.supports(FEATURE_DROP_COLUMN) {
+		return "", b.errUnsupportedFeature("dropping a column")
+	}
+
+	if b.syntax() == DIALECT_MSSQL {
 		sqlString = "ALTER TABLE " + b.quoteTable(tableName) + " DROP COLUMN " + b.quoteColumn(columnName) + ";"
 		return sqlString, nil
 	}
 
-	if b.Dialect == DIALECT_SQLITE {
+	if b.syntax() == DIALECT_SQLITE {
 		sqlString = "ALTER TABLE " + b.quoteTable(tableName) + " DROP COLUMN " + b.quoteColumn(columnName) + ";"
 		return sqlString, nil
 	}
 
-	if b.Dialect == DIALECT_MYSQL {
+	if b.syntax() == DIALECT_MYSQL {
 		sqlString = "ALTER TABLE " + b.quoteTable(tableName) + " DROP COLUMN " + b.quoteColumn(columnName) + ";"
 		return sqlString, nil
 	}
 
-	if b.Dialect == DIALECT_POSTGRES {
+	if b.syntax() == DIALECT_POSTGRES {
 		sqlString = "ALTER TABLE " + b.quoteTable(tableName) + " DROP COLUMN " + b.quoteColumn(columnName) + ";"
 		return sqlString, nil
 	}
 
-	return "", errors.New("dropping a column is not supported for driver " + b.Dialect + "")
+	return "", b.errUnsupportedFeature("dropping a column")
 }
 
 // TableColumnExists checks if a column exists in a table for various database types
@@ -442,40 +645,54 @@ func (b *Builder) TableColumnDrop(tableName string, columnName string) (sqlStrin
 // - params: An array of parameters to be bound to the statement.
 // - err: An error object, if any.
 func (b *Builder) TableColumnExists(tableName, columnName string) (sql string, params []interface{}, err error) {
-	switch b.Dialect {
+	switch b.syntax() {
 	case DIALECT_MYSQL:
 		return "SELECT 1 FROM information_schema.COLUMNS WHERE TABLE_NAME = ? AND COLUMN_NAME = ?", []interface{}{tableName, columnName}, nil
 	case DIALECT_POSTGRES:
 		return "SELECT EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = $1 AND column_name = $2)", []interface{}{tableName, columnName}, nil
 	case DIALECT_SQLITE:
 		return "SELECT 1 FROM pragma_table_info(?) WHERE name = ?", []interface{}{tableName, columnName}, nil
+	case DIALECT_MSSQL:
+		return "SELECT 1 FROM INFORMATION_SCHEMA.COLUMNS WHERE TABLE_NAME = @p1 AND COLUMN_NAME = @p2", []interface{}{tableName, columnName}, nil
 	default:
-		return "", nil, fmt.Errorf("database type '%s' not supported", b.Dialect)
+		return "", nil, errUnsupportedDialect(b.Dialect)
 	}
 }
 
 func (b *Builder) TableColumnRename(tableName, oldColumnName, newColumnName string) (sql string, err error) {
-	if b.Dialect == DIALECT_MSSQL {
-		sql = "EXEC sp_rename " + b.quoteTable(tableName) + "." + b.quoteTable(oldColumnName) + ", " + b.quoteTable(newColumnName) + ", 'COLUMN';"
+	defer recoverError(&err)
+
+	if b.err != nil {
+		return "", b.err
+	}
+
+	if !b.supports(FEATURE_RENAME_COLUMN) {
+		return "", b.errUnsupportedFeature("renaming a column")
+	}
+
+	if b.syntax() == DIALECT_MSSQL {
+		// The new name is taken literally by sp_rename, so it is not quoted
+		b.validateIdentifier(newColumnName)
+		sql = "EXEC sp_rename " + b.mssqlStringToSql(b.quoteTable(tableName)+"."+b.quoteColumn(oldColumnName)) + ", " + b.mssqlStringToSql(newColumnName) + ", 'COLUMN';"
 		return sql, nil
 	}
 
-	if b.Dialect == DIALECT_SQLITE {
+	if b.syntax() == DIALECT_SQLITE {
 		sql = "ALTER TABLE " + b.quoteTable(tableName) + " RENAME COLUMN " + b.quoteTable(oldColumnName) + " TO " + b.quoteTable(newColumnName) + ";"
 		return sql, nil
 	}
 
-	if b.Dialect == DIALECT_MYSQL {
+	if b.syntax() == DIALECT_MYSQL {
 		sql = "ALTER TABLE " + b.quoteTable(tableName) + " RENAME COLUMN " + b.quoteTable(oldColumnName) + " TO " + b.quoteTable(newColumnName) + ";"
 		return sql, nil
 	}
 
-	if b.Dialect == DIALECT_POSTGRES {
+	if b.syntax() == DIALECT_POSTGRES {
 		sql = "ALTER TABLE " + b.quoteTable(tableName) + " RENAME COLUMN " + b.quoteTable(oldColumnName) + " TO " + b.quoteTable(newColumnName) + ";"
 		return sql, nil
 	}
 
-	return "", errors.New("renaming a column is not supported for driver " + b.Dialect + "")
+	return "", b.errUnsupportedFeature("renaming a column")
 }
 
 /** The <b>select</b> method selects rows from a table, based on criteria.
@@ -493,15 +710,59 @@ func (b *Builder) TableColumnRename(tableName, oldColumnName, newColumnName stri
  * @access public
  */
 func (b *Builder) Select(columns []string) string {
-	if b.sqlTableName == "" {
-		panic("In method Delete() no table specified to delete from!")
+	b.failOnError()
+
+	if b.sqlTableName == "" && b.sqlFromSubquery == nil {
+		b.fail(errNoTable("Select"))
 	}
 
-	join := "" // TODO add support for joins
+	sql := b.selectToSql(columns)
 
-	groupBy := ""
-	if len(b.sqlGroupBy) > 0 {
-		groupBy = b.groupByToSql(b.sqlGroupBy)
+	if sql == "" {
+		return ""
+	}
+
+	return sql + ";"
+}
+
+// selectToSql converts the select statement to SQL, without the
+// closing semicolon, so it can be embedded in other statements.
+// The parts are converted in the order they appear in the SQL,
+// to keep the parameters in order
+func (b *Builder) selectToSql(columns []string) string {
+	with := ""
+	if len(b.sqlWith) > 0 {
+		with = b.withToSql(b.sqlWith)
+	}
+
+	selectList := []string{}
+
+	for _, column := range append(slices.Clone(b.sqlSelectColumns), columns...) {
+		selectList = append(selectList, b.quoteAllowedColumn(column))
+	}
+
+	if len(b.sqlSelectExpressions) > 0 {
+		selectList = append(selectList, b.selectExpressionsToSql(b.sqlSelectExpressions))
+	}
+
+	if len(b.sqlSelectSubqueries) > 0 {
+		selectList = append(selectList, b.selectSubqueriesToSql(b.sqlSelectSubqueries))
+	}
+
+	columnsStr := lo.Ternary(len(selectList) > 0, strings.Join(selectList, ", "), "*")
+
+	lockHint := b.lockHintToSql()
+
+	from := ""
+	if b.sqlFromSubquery != nil {
+		from = b.subqueryToSql(*b.sqlFromSubquery) + " AS " + b.quoteTable(b.sqlFromSubquery.Alias)
+	} else {
+		from = b.quoteTable(b.sqlTableName) + lockHint
+	}
+
+	join := ""
+	if len(b.sqlJoin) > 0 {
+		join = b.joinToSql(b.sqlJoin)
 	}
 
 	where := ""
@@ -509,41 +770,44 @@ func (b *Builder) Select(columns []string) string {
 		where = b.whereToSql(b.sqlWhere)
 	}
 
-	orderBy := ""
-	if len(b.sqlOrderBy) > 0 {
-		orderBy = b.orderByToSql(b.sqlOrderBy)
+	groupBy := ""
+	if len(b.sqlGroupBy) > 0 {
+		groupBy = b.groupByToSql(b.sqlGroupBy)
 	}
 
-	limit := ""
-	if b.sqlLimit > 0 {
-		limit = " LIMIT " + strconv.FormatInt(b.sqlLimit, 10)
+	having := ""
+	if len(b.sqlHaving) > 0 {
+		having = b.havingToSql(b.sqlHaving)
 	}
 
-	offset := ""
-	if b.sqlOffset > 0 {
-		offset = " OFFSET " + strconv.FormatInt(b.sqlOffset, 10)
+	// MSSQL limits a simple select with TOP, and pages with OFFSET ... FETCH
+	top := ""
+	if b.syntax() == DIALECT_MSSQL && b.sqlLimit > 0 && b.sqlOffset <= 0 && len(b.sqlCompound) == 0 {
+		top = "TOP " + strconv.FormatInt(b.sqlLimit, 10) + " "
 	}
 
-	columnsStr := "*"
+	sql := "SELECT " + b.distinctToSql() + top + columnsStr + " FROM " + from + join + where + groupBy + having
 
-	if len(columns) > 0 {
-		for index, column := range columns {
-			if strings.Contains(column, "(") {
-				columns[index] = column // Do not quote function calls
-			} else {
-				columns[index] = b.quoteColumn(column)
-			}
-		}
-		columnsStr = strings.Join(columns, ", ")
+	// The order and paging of a compound select apply to the combined result
+	if len(b.sqlCompound) > 0 {
+		sql = b.compoundToSql(sql, b.sqlCompound)
 	}
 
-	sql := ""
+	orderBy := ""
+	if len(b.sqlOrderBy) > 0 {
+		orderBy = b.orderByToSql(b.sqlOrderBy)
+	}
 
-	if b.Dialect == DIALECT_MYSQL || b.Dialect == DIALECT_POSTGRES || b.Dialect == DIALECT_SQLITE {
-		sql = "SELECT " + columnsStr + " FROM " + b.quoteTable(b.sqlTableName) + join + where + groupBy + orderBy + limit + offset + ";"
+	if top != "" {
+		return with + sql + orderBy
 	}
 
-	return sql
+	return with + sql + orderBy + b.limitOffsetToSql(orderBy != "") + b.lockToSql()
+}
+
+// limitOffsetToSql converts the limit and offset to SQL, as the dialect pages
+func (b *Builder) limitOffsetToSql(hasOrderBy bool) string {
+	return b.dialectOrFail().LimitOffset(b.sqlLimit, b.sqlOffset, hasOrderBy)
 }
 
 /**
@@ -557,8 +821,22 @@ func (b *Builder) Select(columns []string) string {
  * @access public
  */
 func (b *Builder) Insert(columnValuesMap map[string]string) string {
+	return b.insertToSql("Insert", lo.MapValues(columnValuesMap, func(value string, _ string) any {
+		return value
+	}))
+}
+
+// insertToSql converts an insert of typed values to SQL
+func (b *Builder) insertToSql(method string, columnValuesMap map[string]any) string {
+	b.failOnError()
+
 	if b.sqlTableName == "" {
-		panic("In method Insert() no table specified to insert in!")
+		b.fail(errNoTable(method))
+	}
+
+	// MSSQL has no LIMIT, and no TOP in a single row INSERT
+	if b.syntax() == DIALECT_MSSQL && (b.sqlLimit > 0 || b.sqlOffset > 0) {
+		b.fail(b.errUnsupportedFeature("in method " + method + "() limit and offset"))
 	}
 
 	limit := ""
@@ -584,14 +862,68 @@ func (b *Builder) Insert(columnValuesMap map[string]string) string {
 	for _, columnName := range keys {
 		columnValue := columnValuesMap[columnName]
 		columnNames = append(columnNames, b.quoteColumn(columnName))
-		columnValues = append(columnValues, b.quoteValue(columnValue))
+		columnValues = append(columnValues, b.quoteValueAny(columnValue))
 	}
 
-	return "INSERT INTO " + b.quoteTable(b.sqlTableName) + " (" + strings.Join(columnNames, ", ") + ") VALUES (" + strings.Join(columnValues, ", ") + ")" + limit + offset + ";"
+	return "INSERT INTO " + b.quoteTable(b.sqlTableName) + " (" + strings.Join(columnNames, ", ") + ")" + b.outputToSql("INSERTED") + " VALUES (" + strings.Join(columnValues, ", ") + ")" + b.returningToSql() + limit + offset + ";"
 }
 
-func (b *Builder) Truncate() string {
-	// TODO: implement
+// Truncate removes all the rows from the table. SQLite has no TRUNCATE,
+// so it is emulated with DELETE FROM, and the auto increment counter
+// is reset in the sqlite_sequence table (which exists only if there is
+// a table with an AUTOINCREMENT column)
+//
+//	Example:
+//	// TRUNCATE TABLE "users" RESTART IDENTITY CASCADE;
+//	NewBuilder(DIALECT_POSTGRES).
+//		Table("users").
+//		Truncate(TruncateOptions{RestartIdentity: true, Cascade: true})
+func (b *Builder) Truncate(options ...TruncateOptions) string {
+	b.failOnError()
+
+	if b.sqlTableName == "" {
+		b.fail(errNoTable("Truncate"))
+	}
+
+	option := TruncateOptions{}
+	if len(options) > 0 {
+		option = options[0]
+	}
+
+	if option.Cascade && !b.supports(FEATURE_TRUNCATE_CASCADE) {
+		b.fail(b.errUnsupportedFeature("truncate cascade"))
+	}
+
+	table := b.quoteTable(b.sqlTableName)
+
+	switch b.syntax() {
+	case DIALECT_MYSQL, DIALECT_MSSQL:
+		return "TRUNCATE TABLE " + table + ";"
+	case DIALECT_POSTGRES:
+		sql := "TRUNCATE TABLE " + table
+		if option.RestartIdentity {
+			sql += " RESTART IDENTITY"
+		}
+		if option.Cascade {
+			sql += " CASCADE"
+		}
+		return sql + ";"
+	case DIALECT_SQLITE:
+		sql := "DELETE FROM " + table + ";"
+		if option.RestartIdentity {
+			// The sequences are kept per schema, by the bare table name
+			sequence := "sqlite_sequence"
+			tableName := b.sqlTableName
+			if schema, name, found := strings.Cut(b.sqlTableName, "."); found {
+				sequence = schema + "." + sequence
+				tableName = name
+			}
+			sql += " DELETE FROM " + b.quoteTable(sequence) + ` WHERE "name" = ` + b.quote(tableName, "value") + ";"
+		}
+		return sql
+	}
+
+	b.fail(errUnsupportedDialect(b.Dialect))
 	return ""
 }
 
@@ -606,11 +938,55 @@ func (b *Builder) Truncate() string {
  * @access public
  */
 func (b *Builder) Update(columnValues map[string]string) string {
+	return b.updateToSql(lo.MapValues(columnValues, func(value string, _ string) any {
+		return value
+	}))
+}
+
+// updateToSql converts an update of typed values to SQL
+func (b *Builder) updateToSql(columnValues map[string]any) string {
+	b.failOnError()
+
 	if b.sqlTableName == "" {
-		panic("In method Delete() no table specified to delete from!")
+		b.fail(errNoTable("Update"))
+	}
+
+	with := ""
+	if len(b.sqlWith) > 0 {
+		with = b.withToSql(b.sqlWith)
+	}
+
+	// MySQL joins the tables before the SET clause
+	join := ""
+	if len(b.sqlJoin) > 0 && b.syntax() == DIALECT_MYSQL {
+		join = b.joinToSql(b.sqlJoin)
+	}
+
+	// Order keys
+	keys := make([]string, 0, len(columnValues))
+	for k := range columnValues {
+		keys = append(keys, k)
 	}
+	sort.Strings(keys)
 
-	join := "" // TODO add support for joins
+	// The SET values come before the WHERE values, so they
+	// must be quoted first to keep the parameters in order
+	updateSql := []string{}
+	for _, columnName := range keys {
+		columnValue := columnValues[columnName]
+		updateSql = append(updateSql, b.quoteColumn(columnName)+"="+b.quoteValueAny(columnValue))
+	}
+
+	// The other dialects join the tables in a FROM clause after the SET clause
+	from := ""
+	joinConditions := ""
+	if len(b.sqlJoin) > 0 && b.syntax() == DIALECT_MSSQL {
+		from = " FROM " + b.quoteTable(b.sqlTableName) + b.joinToSql(b.sqlJoin)
+	}
+	if len(b.sqlJoin) > 0 && (b.syntax() == DIALECT_POSTGRES || b.syntax() == DIALECT_SQLITE) {
+		from, joinConditions = b.joinToSqlAsFrom(b.sqlJoin, "Update")
+		from = " FROM " + from
+	}
 
 	groupBy := ""
 	if len(b.sqlGroupBy) > 0 {
@@ -618,8 +994,8 @@ func (b *Builder) Update(columnValues map[string]string) string {
 	}
 
 	where := ""
-	if len(b.sqlWhere) > 0 {
-		where = b.whereToSql(b.sqlWhere)
+	if len(b.sqlWhere) > 0 || joinConditions != "" {
+		where = b.joinWhereToSql(joinConditions)
 	}
 
 	orderBy := ""
@@ -637,23 +1013,15 @@ func (b *Builder) Update(columnValues map[string]string) string {
 		offset = " OFFSET " + strconv.FormatInt(b.sqlOffset, 10)
 	}
 
-	// Order keys
-	keys := make([]string, 0, len(columnValues))
-	for k := range columnValues {
-		keys = append(keys, k)
+	if b.syntax() == DIALECT_MSSQL {
+		return with + "UPDATE" + b.mssqlTopToSql("Update") + " " + b.quoteTable(b.sqlTableName) + " SET " + strings.Join(updateSql, ", ") + b.outputToSql("INSERTED") + from + where + ";"
 	}
-	sort.Strings(keys)
 
-	updateSql := []string{}
-	for _, columnName := range keys {
-		columnValue := columnValues[columnName]
-		updateSql = append(updateSql, b.quoteColumn(columnName)+"="+b.quoteValue(columnValue))
-	}
-
-	return "UPDATE " + b.quoteTable(b.sqlTableName) + " SET " + strings.Join(updateSql, ", ") + join + where + groupBy + orderBy + limit + offset + ";"
+	return with + "UPDATE " + b.quoteTable(b.sqlTableName) + join + " SET " + strings.Join(updateSql, ", ") + b.outputToSql("INSERTED") + from + where + b.returningToSql() + groupBy + orderBy + limit + offset + ";"
 }
 
 func (b *Builder) Where(where Where) BuilderInterface {
+	b = b.mutable()
 	b.sqlWhere = append(b.sqlWhere, where)
 	return b
 }
@@ -664,6 +1032,7 @@ func (b *Builder) columnsToSQL(columns []Column) string {
 
 	for i := 0; i < len(columns); i++ {
 		column := columns[i]
+		b.validateIdentifier(column.Name)
 		columnSQLs = append(columnSQLs, b.columnSQLGenerator.GenerateSQL(column))
 	}
 
@@ -673,7 +1042,7 @@ func (b *Builder) columnsToSQL(columns []Column) string {
 func (b *Builder) groupByToSql(groupBys []GroupBy) string {
 	sql := []string{}
 	for _, groupBy := range groupBys {
-		sql = append(sql, b.quoteColumn(groupBy.Column))
+		sql = append(sql, b.quoteAllowedColumn(groupBy.Column))
 	}
 
 	if len(sql) > 0 {
@@ -683,67 +1052,11 @@ func (b *Builder) groupByToSql(groupBys []GroupBy) string {
 	return ""
 }
 
-// /**
-//      * Joins tables to SQL.
-//      * @return String the join SQL string
-//      * @access private
-//      */
-// 	 private function join_to_sql($join, $table_name)
-// 	 {
-// 		 $sql = '';
-// 		 // MySQL
-// 		 if ($this->database_type == 'mysql') {
-// 			 foreach ($join as $what) {
-// 				 $type = $what[3] ?? '';
-// 				 $alias = $what[4] ?? '';
-// 				 $sql .= ' ' . $type . ' JOIN `' . $what[0] . '`';
-// 				 if ($alias != "") {
-// 					 $sql .= ' AS ' . $alias . '';
-// 					 $what[0] = $alias;
-// 				 }
-// 				 if ($what[1] == $what[2]) {
-// 					 $sql .= ' USING (`' . $what[1] . '`)';
-// 				 } else {
-// 					 $sql .= ' ON ' . $table_name . '.' . $what[1] . '=' . $what[0] . '.' . $what[2];
-// 				 }
-// 			 }
-// 		 }
-// 		 // SQLite
-// 		 if ($this->database_type == 'sqlite' or $this->database_type == 'sqlitedb') {
-// 			 foreach ($join as $what) {
-// 				 $type = $what[3] ?? '';
-// 				 $alias = $what[4] ?? '';
-// 				 $sql .= " $type JOIN '" . $what[0] . "'";
-// 				 if ($alias != "") {
-// 					 $sql .= " AS '$alias'";
-// 					 $what[0] = $alias;
-// 				 }
-// 				 $sql .= ' ON ' . $table_name . '.' . $what[1] . '=' . $what[0] . '.' . $what[2];
-// 			 }
-// 		 }
-
-// 		 return $sql;
-// 	 }
-
 func (b *Builder) orderByToSql(orderBys []OrderBy) string {
 	sql := []string{}
 
-	if b.Dialect == DIALECT_MYSQL {
-		for _, orderBy := range orderBys {
-			sql = append(sql, b.quoteColumn(orderBy.Column)+" "+orderBy.Direction)
-		}
-	}
-
-	if b.Dialect == DIALECT_POSTGRES {
-		for _, orderBy := range orderBys {
-			sql = append(sql, b.quoteColumn(orderBy.Column)+" "+orderBy.Direction)
-		}
-	}
-
-	if b.Dialect == DIALECT_SQLITE {
-		for _, orderBy := range orderBys {
-			sql = append(sql, b.quoteColumn(orderBy.Column)+" "+orderBy.Direction)
-		}
+	for _, orderBy := range orderBys {
+		sql = append(sql, b.quoteAllowedColumn(orderBy.Column)+" "+orderBy.Direction)
 	}
 
 	if len(sql) > 0 {
@@ -753,6 +1066,8 @@ func (b *Builder) orderByToSql(orderBys []OrderBy) string {
 	return ""
 }
 
+// quoteColumn quotes a column, qualified with the table or not.
+// Every part is quoted, the function calls are added with SelectExpression
 func (b *Builder) quoteColumn(columnName string) string {
 	columnSplit := strings.Split(columnName, ".")
 	columnQuoted := []string{}
@@ -763,10 +1078,6 @@ func (b *Builder) quoteColumn(columnName string) string {
 			continue
 		}
 
-		if strings.Contains(columnPart, "(") {
-			columnQuoted = append(columnQuoted, columnPart)
-		}
-
 		columnQuoted = append(columnQuoted, b.quote(columnPart, "column"))
 	}
 
@@ -69,7 +69,7 @@ func TestBuilderTableCreateMssql(t *testing.T) {
 		}).
 		Create()
 
-	expected := `CREATE TABLE [users] ("id" NVARCHAR(40) PRIMARY KEY NOT NULL, "email" NVARCHAR(255) NOT NULL UNIQUE, "image" VARBINARY(MAX) NOT NULL, "price_default" DECIMAL(10,2) NOT NULL, "price_custom" DECIMAL(12,10) NOT NULL, "created_at" DATETIME2 NOT NULL, "deleted_at" DATETIME2);`
+	expected := `CREATE TABLE [users] ([id] NVARCHAR(40) PRIMARY KEY NOT NULL, [email] NVARCHAR(255) NOT NULL UNIQUE, [image] VARBINARY(MAX) NOT NULL, [price_default] DECIMAL(10,2) NOT NULL, [price_custom] DECIMAL(12,10) NOT NULL, [created_at] DATETIME2 NOT NULL, [deleted_at] DATETIME2);`
 	if sql != expected {
 		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
 	}
@@ -402,28 +402,47 @@ func TestBuilderTableColumnChangePostgres(t *testing.T) {
 		t.Fatal("Error must be NIL but got: ", err.Error())
 	}
 
-	expected := `ALTER TABLE "users" ALTER COLUMN "email" TEXT NOT NULL UNIQUE;`
+	expected := `ALTER TABLE "users" ALTER COLUMN "email" TYPE TEXT, ALTER COLUMN "email" SET NOT NULL, ALTER COLUMN "email" DROP DEFAULT, ADD UNIQUE ("email");`
 	if sql != expected {
 		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
 	}
-}
 
-func TestBuilderTableColumnChangeSqlite(t *testing.T) {
-	sql, err := NewBuilder(DIALECT_SQLITE).TableColumnChange("users", Column{
-		Name:   "email",
-		Type:   COLUMN_TYPE_STRING,
-		Length: 255,
-		Unique: true,
+	sql, err = NewBuilder(DIALECT_POSTGRES).TableColumnChange("users", Column{
+		Name:     "price",
+		Type:     COLUMN_TYPE_DECIMAL,
+		Nullable: true,
+		Default:  "0",
+
+		DefaultExpression: true,
 	})
 
 	if err != nil {
 		t.Fatal("Error must be NIL but got: ", err.Error())
 	}
 
-	expected := `ALTER TABLE "users" ALTER COLUMN "email" TEXT(255) NOT NULL UNIQUE;`
+	expected = `ALTER TABLE "users" ALTER COLUMN "price" TYPE DECIMAL(10,2), ALTER COLUMN "price" DROP NOT NULL, ALTER COLUMN "price" SET DEFAULT 0;`
 	if sql != expected {
 		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
 	}
+
+	_, err = NewBuilder(DIALECT_POSTGRES).TableColumnChange("users", Column{Name: "id", Type: COLUMN_TYPE_INTEGER, AutoIncrement: true})
+
+	if !errors.Is(err, ErrUnsupportedFeature) {
+		t.Fatal("Expected ErrUnsupportedFeature for auto increment but found: ", err)
+	}
+}
+
+func TestBuilderTableColumnChangeSqlite(t *testing.T) {
+	_, err := NewBuilder(DIALECT_SQLITE).TableColumnChange("users", Column{
+		Name:   "email",
+		Type:   COLUMN_TYPE_STRING,
+		Length: 255,
+		Unique: true,
+	})
+
+	if !errors.Is(err, ErrUnsupportedFeature) {
+		t.Fatal("Expected ErrUnsupportedFeature, as SQLite can not alter a column, but found: ", err)
+	}
 }
 
 func TestBuilderViewCreateMysql(t *testing.T) {
@@ -773,7 +792,7 @@ func TestBuilderTableDeleteMysqlExtended(t *testing.T) {
 		Offset(34).
 		Delete()
 
-	expected := "DELETE FROM `users` WHERE `FirstName` = \"Tom\" OR `FirstName` = \"Sam\" LIMIT 12 OFFSET 34;"
+	expected := "DELETE FROM `users` WHERE `FirstName` = 'Tom' OR `FirstName` = 'Sam' LIMIT 12 OFFSET 34;"
 	if sql != expected {
 		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
 	}
@@ -855,7 +874,7 @@ func TestBuilderTableSelectFullMysql(t *testing.T) {
 		GroupBy(GroupBy{Column: "passport"}).
 		Select([]string{"id", "first_name", "last_name"})
 
-	expected := "SELECT `id`, `first_name`, `last_name` FROM `users` WHERE `first_name` <> \"Jane\" GROUP BY `passport` ORDER BY `first_name` ASC LIMIT 10 OFFSET 20;"
+	expected := "SELECT `id`, `first_name`, `last_name` FROM `users` WHERE `first_name` <> 'Jane' GROUP BY `passport` ORDER BY `first_name` ASC LIMIT 10 OFFSET 20;"
 	if sql != expected {
 		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
 	}
@@ -871,7 +890,7 @@ func TestBuilderTableSelectFullPostgres(t *testing.T) {
 		GroupBy(GroupBy{Column: "passport"}).
 		Select([]string{"id", "first_name", "last_name"})
 
-	expected := `SELECT "id", "first_name", "last_name" FROM "users" WHERE "first_name" <> "Jane" GROUP BY "passport" ORDER BY "first_name" ASC LIMIT 10 OFFSET 20;`
+	expected := `SELECT "id", "first_name", "last_name" FROM "users" WHERE "first_name" <> 'Jane' GROUP BY "passport" ORDER BY "first_name" ASC LIMIT 10 OFFSET 20;`
 	if sql != expected {
 		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
 	}
@@ -902,7 +921,7 @@ func TestBuilderTableInsertMysql(t *testing.T) {
 			"last_name":  "Jones",
 		})
 
-	expected := "INSERT INTO `users` (`first_name`, `last_name`) VALUES (\"Tom\", \"Jones\") LIMIT 1;"
+	expected := "INSERT INTO `users` (`first_name`, `last_name`) VALUES ('Tom', 'Jones') LIMIT 1;"
 	if sql != expected {
 		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
 	}
@@ -917,7 +936,7 @@ func TestBuilderTableInsertPostgres(t *testing.T) {
 			"last_name":  "Jones",
 		})
 
-	expected := `INSERT INTO "users" ("first_name", "last_name") VALUES ("Tom", "Jones") LIMIT 1;`
+	expected := `INSERT INTO "users" ("first_name", "last_name") VALUES ('Tom', 'Jones') LIMIT 1;`
 	if sql != expected {
 		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
 	}
@@ -970,7 +989,7 @@ func TestBuilderTableUpdateMysql(t *testing.T) {
 			"last_name":  "Jones",
 		})
 
-	expected := "UPDATE `users` SET `first_name`=\"Tom\", `last_name`=\"Jones\" WHERE `id` = \"1\" LIMIT 1;"
+	expected := "UPDATE `users` SET `first_name`='Tom', `last_name`='Jones' WHERE `id` = '1' LIMIT 1;"
 	if sql != expected {
 		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
 	}
@@ -990,7 +1009,7 @@ func TestBuilderTableUpdatePostgres(t *testing.T) {
 			"last_name":  "Jones",
 		})
 
-	expected := `UPDATE "users" SET "first_name"="Tom", "last_name"="Jones" WHERE "id" = "1" LIMIT 1;`
+	expected := `UPDATE "users" SET "first_name"='Tom', "last_name"='Jones' WHERE "id" = '1' LIMIT 1;`
 	if sql != expected {
 		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
 	}
@@ -1022,7 +1041,7 @@ func TestBuilderTableSelectMysqlInj(t *testing.T) {
 		Where(Where{Column: "id", Operator: "=", Value: "58\" OR 1 = 1;--"}).
 		Select([]string{})
 
-	expected := "SELECT * FROM `users` WHERE `id` = \"58\"\" OR 1 = 1;--\";"
+	expected := "SELECT * FROM `users` WHERE `id` = '58\" OR 1 = 1;--';"
 	if sql != expected {
 		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
 	}
@@ -1034,7 +1053,7 @@ func TestBuilderTableSelectPostgreslInj(t *testing.T) {
 		Where(Where{Column: "id", Operator: "=", Value: "58\" OR 1 = 1;--"}).
 		Select([]string{})
 
-	expected := `SELECT * FROM "users" WHERE "id" = "58"" OR 1 = 1;--";`
+	expected := `SELECT * FROM "users" WHERE "id" = '58" OR 1 = 1;--';`
 	if sql != expected {
 		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
 	}
@@ -1066,9 +1085,10 @@ func TestBuilderTableSelectAll(t *testing.T) {
 func TestBuilderTableSelectFn(t *testing.T) {
 	sql := NewBuilder(DIALECT_SQLITE).
 		Table("users").
-		Select([]string{"MIN(created_at)"})
+		SelectExpression(Expression{Function: FUNCTION_MIN, Columns: []string{"created_at"}}).
+		Select([]string{})
 
-	expected := `SELECT MIN(created_at) FROM "users";`
+	expected := `SELECT MIN("created_at") FROM "users";`
 	if sql != expected {
 		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
 	}
@@ -1107,6 +1127,51 @@ func TestBuilderViewDropSqlite(t *testing.T) {
 	}
 }
 
+func TestBuilderTruncate(t *testing.T) {
+	tests := []struct {
+		dialect  string
+		options  []TruncateOptions
+		expected string
+	}{
+		{DIALECT_MYSQL, nil, "TRUNCATE TABLE `users`;"},
+		{DIALECT_MSSQL, nil, "TRUNCATE TABLE [users];"},
+		{DIALECT_POSTGRES, nil, `TRUNCATE TABLE "users";`},
+		{DIALECT_POSTGRES, []TruncateOptions{{RestartIdentity: true}}, `TRUNCATE TABLE "users" RESTART IDENTITY;`},
+		{DIALECT_POSTGRES, []TruncateOptions{{RestartIdentity: true, Cascade: true}}, `TRUNCATE TABLE "users" RESTART IDENTITY CASCADE;`},
+		{DIALECT_SQLITE, nil, `DELETE FROM "users";`},
+		{DIALECT_SQLITE, []TruncateOptions{{RestartIdentity: true}}, `DELETE FROM "users"; DELETE FROM "sqlite_sequence" WHERE "name" = 'users';`},
+	}
+
+	for _, test := range tests {
+		sql := NewBuilder(test.dialect).Table("users").Truncate(test.options...)
+
+		if sql != test.expected {
+			t.Fatal("Expected:\n", test.expected, "\nbut found:\n", sql)
+		}
+	}
+}
+
+func TestBuilderTruncateSqliteSchema(t *testing.T) {
+	sql := NewBuilder(DIALECT_SQLITE).Table("main.users").Truncate(TruncateOptions{RestartIdentity: true})
+
+	expected := `DELETE FROM "main"."users"; DELETE FROM "main"."sqlite_sequence" WHERE "name" = 'users';`
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+}
+
+func TestBuilderTruncateCascadeMysqlPanics(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("Truncate with cascade must panic for MySQL")
+		}
+	}()
+
+	NewBuilder(DIALECT_MYSQL).
+		Table("users").
+		Truncate(TruncateOptions{Cascade: true})
+}
+
 func TestBuilder_TableColumnChange(t *testing.T) {
 	type args struct {
 		tableName string
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"log"
+	"sync"
 	"time"
 
 	"github.com/georgysavva/scany/sqlscan"
@@ -31,6 +32,8 @@ type Database struct {
 	sqlLog         map[string]string
 	sqlDurationLog map[string]time.Duration
 	debug          bool
+	version        string
+	versionMutex   sync.Mutex
 }
 
 // == INTERFACE ==============================================================
@@ -316,6 +319,64 @@ func (d *Database) SelectToMapString(sqlStr string, args ...any) ([]map[string]s
 	return listMapString, nil
 }
 
+// ExecToMapAny executes a statement returning rows, i.e. an INSERT, UPDATE
+// or DELETE with RETURNING (or OUTPUT), and scans the rows into maps.
+// Unlike SelectToMapAny, it runs in the transaction, if one is in progress
+func (d *Database) ExecToMapAny(sqlStr string, args ...any) ([]map[string]any, error) {
+	if d.sqlLogEnabled {
+		if d.sqlLog == nil {
+			d.sqlLog = map[string]string{}
+			d.sqlDurationLog = map[string]time.Duration{}
+		}
+
+		sqlID := uid.HumanUid()
+
+		d.sqlLog[sqlID] = sqlStr
+
+		start := time.Now()
+		defer func() {
+			d.sqlDurationLog[sqlID] = time.Since(start)
+		}()
+	}
+
+	if d.debug {
+		log.Println(sqlStr)
+	}
+
+	listMap := []map[string]any{}
+
+	var err error
+	if d.tx != nil {
+		err = sqlscan.Select(context.Background(), d.tx, &listMap, sqlStr, args...)
+	} else {
+		err = sqlscan.Select(context.Background(), d.db, &listMap, sqlStr, args...)
+	}
+
+	if err != nil {
+		return []map[string]any{}, err
+	}
+
+	return listMap, nil
+}
+
+// ExecToMapString works like ExecToMapAny, but converts the values to strings
+func (d *Database) ExecToMapString(sqlStr string, args ...any) ([]map[string]string, error) {
+	listMapAny, err := d.ExecToMapAny(sqlStr, args...)
+
+	if err != nil {
+		return []map[string]string{}, err
+	}
+
+	listMapString := []map[string]string{}
+
+	for i := 0; i < len(listMapAny); i++ {
+		mapString := maputils.MapStringAnyToMapStringString(listMapAny[i])
+		listMapString = append(listMapString, mapString)
+	}
+
+	return listMapString, nil
+}
+
 func (d *Database) Tx() *sql.Tx {
 	return d.tx
 }
@@ -0,0 +1,40 @@
+package sb
+
+import (
+	"slices"
+)
+
+// AllowColumns enables the strict mode, where the columns of the select
+// list (including the distinct on, the expression arguments and their
+// windows), the where and having conditions, the group by and the order
+// by must be one of the allowed columns (as written, i.e. "users.id").
+// It is meant for the column names coming from the user, i.e. sort and
+// filter parameters. A column not allowed fails the query with ErrInvalidQuery
+//
+//	Example:
+//	sql, params, err := NewBuilder(DIALECT_POSTGRES).
+//		Table("users").
+//		AllowColumns("id", "name", "created_at").
+//		OrderBy(request.URL.Query().Get("sort"), ASC).
+//		Build()
+func (b *Builder) AllowColumns(columns ...string) BuilderInterface {
+	b = b.mutable()
+
+	if b.sqlAllowedColumns == nil {
+		b.sqlAllowedColumns = []string{}
+	}
+
+	b.sqlAllowedColumns = append(b.sqlAllowedColumns, columns...)
+
+	return b
+}
+
+// quoteAllowedColumn quotes a column of the query, which
+// in the strict mode must be one of the allowed columns
+func (b *Builder) quoteAllowedColumn(column string) string {
+	if b.sqlAllowedColumns != nil && !slices.Contains(b.sqlAllowedColumns, column) {
+		b.fail(errInvalidQuery("column " + column + " is not allowed"))
+	}
+
+	return b.quoteColumn(column)
+}
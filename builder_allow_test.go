@@ -0,0 +1,79 @@
+package sb
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBuilderAllowColumns(t *testing.T) {
+	sql, params, err := NewBuilder(DIALECT_POSTGRES).
+		Table("users").
+		AllowColumns("id", "name").
+		SelectColumns("id").
+		SelectExpression(Expression{Function: FUNCTION_COUNT}).
+		SelectExpression(Expression{Function: FUNCTION_ROW_NUMBER, Window: &Window{PartitionBy: []string{"name"}, OrderBy: []OrderBy{{Column: "id", Direction: ASC}}}, Alias: "position"}).
+		Where(Where{Column: "name", Operator: "=", Value: "Tom"}).
+		GroupBy(GroupBy{Column: "id"}).
+		OrderBy("name", DESC).
+		Build()
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	expected := `SELECT "id", COUNT(*), ROW_NUMBER() OVER (PARTITION BY "name" ORDER BY "id" ASC) AS "position" FROM "users" WHERE "name" = $1 GROUP BY "id" ORDER BY "name" DESC;`
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+
+	if len(params) != 1 || params[0] != "Tom" {
+		t.Fatal("Expected params [Tom] but found: ", params)
+	}
+}
+
+func TestBuilderAllowColumnsRejects(t *testing.T) {
+	base := NewBuilder(DIALECT_MYSQL).Table("users").AllowColumns("id", "name").Immutable()
+
+	tests := map[string]BuilderInterface{
+		"select":     base.SelectColumns("password"),
+		"where":      base.Where(Where{Column: "password", Operator: "=", Value: "x"}),
+		"where nest": base.WhereGroup(Where{Column: "id", Operator: "=", Value: "1"}, Where{Column: "password", Operator: "=", Value: "x"}),
+		"having":     base.Having(Where{Column: "COUNT(*)", Operator: ">", Value: "1"}),
+		"group by":   base.GroupBy(GroupBy{Column: "password"}),
+		"order by":   base.OrderBy("(SELECT password FROM admins)", ASC),
+		"qualified":  base.OrderBy("users.id", ASC),
+		"expression": base.SelectExpression(Expression{Function: FUNCTION_MAX, Columns: []string{"password"}}),
+		"partition":  base.SelectExpression(Expression{Function: FUNCTION_ROW_NUMBER, Window: &Window{PartitionBy: []string{"password"}}}),
+		"window":     base.SelectExpression(Expression{Function: FUNCTION_RANK, Window: &Window{OrderBy: []OrderBy{{Column: "password", Direction: ASC}}}}),
+	}
+
+	for name, builder := range tests {
+		_, _, err := builder.Build()
+
+		if !errors.Is(err, ErrInvalidQuery) {
+			t.Fatal("Error must be ErrInvalidQuery for ", name, " but got: ", err)
+		}
+	}
+
+	_, _, err := base.OrderBy("name", ASC).Build()
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	_, _, err = NewBuilder(DIALECT_POSTGRES).Table("users").AllowColumns("id").DistinctOn("password").SelectColumns("id").Build()
+	if !errors.Is(err, ErrInvalidQuery) {
+		t.Fatal("Error must be ErrInvalidQuery for distinct on but got: ", err)
+	}
+}
+
+func TestBuilderAllowColumnsEmpty(t *testing.T) {
+	_, _, err := NewBuilder(DIALECT_SQLITE).
+		Table("users").
+		AllowColumns().
+		SelectColumns("id").
+		Build()
+
+	if !errors.Is(err, ErrInvalidQuery) {
+		t.Fatal("Error must be ErrInvalidQuery but got: ", err)
+	}
+}
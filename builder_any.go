@@ -0,0 +1,50 @@
+package sb
+
+// InsertAny works like Insert, but takes typed values, which are
+// converted as expected by the dialect (i.e. nil to NULL, booleans
+// to TRUE or 1, times to the dialect date format, byte slices to
+// binary literals)
+//
+//	Example:
+//	// INSERT INTO "users" ("active", "age", "deleted_at", "name") VALUES (1, 30, NULL, 'Tom');
+//	NewBuilder(DIALECT_SQLITE).
+//		Table("users").
+//		InsertAny(map[string]any{"name": "Tom", "age": 30, "active": true, "deleted_at": nil})
+func (b *Builder) InsertAny(columnValuesMap map[string]any) string {
+	return b.insertToSql("InsertAny", columnValuesMap)
+}
+
+// InsertAnyWithParams works like InsertAny, but returns the values as bound parameters
+func (b *Builder) InsertAnyWithParams(columnValuesMap map[string]any) (sql string, params []any, err error) {
+	defer recoverError(&err)
+
+	if b.sqlTableName == "" {
+		return "", nil, errNoTable("InsertAnyWithParams")
+	}
+
+	sql, params = b.withParams(func(b *Builder) string {
+		return b.InsertAny(columnValuesMap)
+	})
+
+	return sql, params, nil
+}
+
+// UpdateAny works like Update, but takes typed values, converted as in InsertAny
+func (b *Builder) UpdateAny(columnValues map[string]any) string {
+	return b.updateToSql(columnValues)
+}
+
+// UpdateAnyWithParams works like UpdateAny, but returns the values as bound parameters
+func (b *Builder) UpdateAnyWithParams(columnValues map[string]any) (sql string, params []any, err error) {
+	defer recoverError(&err)
+
+	if b.sqlTableName == "" {
+		return "", nil, errNoTable("UpdateAnyWithParams")
+	}
+
+	sql, params = b.withParams(func(b *Builder) string {
+		return b.UpdateAny(columnValues)
+	})
+
+	return sql, params, nil
+}
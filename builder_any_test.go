@@ -0,0 +1,172 @@
+package sb
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+)
+
+func TestBuilderInsertAny(t *testing.T) {
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 600000000, time.UTC)
+
+	tests := []struct {
+		dialect  string
+		expected string
+	}{
+		{DIALECT_MYSQL, "INSERT INTO `users` (`active`, `age`, `avatar`, `created_at`, `deleted_at`, `name`, `score`) VALUES (TRUE, 30, X'cafe', '2024-01-02 03:04:05.6', NULL, 'Tom', 1.5);"},
+		{DIALECT_POSTGRES, `INSERT INTO "users" ("active", "age", "avatar", "created_at", "deleted_at", "name", "score") VALUES (TRUE, 30, '\xcafe'::bytea, '2024-01-02 03:04:05.6+00:00', NULL, 'Tom', 1.5);`},
+		{DIALECT_SQLITE, `INSERT INTO "users" ("active", "age", "avatar", "created_at", "deleted_at", "name", "score") VALUES (1, 30, X'cafe', '2024-01-02 03:04:05.6+00:00', NULL, 'Tom', 1.5);`},
+		{DIALECT_MSSQL, `INSERT INTO [users] ([active], [age], [avatar], [created_at], [deleted_at], [name], [score]) VALUES (1, 30, 0xcafe, N'2024-01-02T03:04:05.6', NULL, N'Tom', 1.5);`},
+	}
+
+	for _, test := range tests {
+		sql := NewBuilder(test.dialect).
+			Table("users").
+			InsertAny(map[string]any{
+				"name":       "Tom",
+				"age":        30,
+				"score":      1.5,
+				"active":     true,
+				"avatar":     []byte{0xca, 0xfe},
+				"created_at": createdAt,
+				"deleted_at": nil,
+			})
+
+		if sql != test.expected {
+			t.Fatal("Expected:\n", test.expected, "\nbut found:\n", sql)
+		}
+	}
+}
+
+func TestBuilderInsertAnyNullables(t *testing.T) {
+	var nilAge *int
+	age := 30
+
+	sql := NewBuilder(DIALECT_SQLITE).
+		Table("users").
+		InsertAny(map[string]any{
+			"a": nilAge,
+			"b": &age,
+			"c": sql.NullString{},
+			"d": sql.NullString{String: "Tom", Valid: true},
+			"e": sql.NullBool{Bool: false, Valid: true},
+		})
+
+	expected := `INSERT INTO "users" ("a", "b", "c", "d", "e") VALUES (NULL, 30, NULL, 'Tom', 0);`
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+}
+
+func TestBuilderUpdateAny(t *testing.T) {
+	sql := NewBuilder(DIALECT_POSTGRES).
+		Table("users").
+		Where(Where{Column: "id", Operator: "=", Values: []any{1}}).
+		UpdateAny(map[string]any{"active": false, "deleted_at": nil})
+
+	expected := `UPDATE "users" SET "active"=FALSE, "deleted_at"=NULL WHERE "id" = 1;`
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+}
+
+func TestBuilderWhereTypedNull(t *testing.T) {
+	var deletedAt *time.Time
+
+	sql := NewBuilder(DIALECT_SQLITE).
+		Table("users").
+		Where(Where{Column: "deleted_at", Operator: "=", Values: []any{deletedAt}}).
+		Where(Where{Column: "email", Operator: "!=", Values: []any{sql.NullString{}}}).
+		Select([]string{})
+
+	expected := `SELECT * FROM "users" WHERE "deleted_at" IS NULL AND "email" IS NOT NULL;`
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+}
+
+func TestBuilderAnyWithParams(t *testing.T) {
+	sql, params, err := NewBuilder(DIALECT_POSTGRES).
+		Table("users").
+		InsertAnyWithParams(map[string]any{"active": true, "avatar": []byte{1}, "deleted_at": nil})
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	expected := `INSERT INTO "users" ("active", "avatar", "deleted_at") VALUES ($1, $2, NULL);`
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+
+	if len(params) != 2 || params[0] != true {
+		t.Fatal("Unexpected params: ", params)
+	}
+
+	sql, params, err = NewBuilder(DIALECT_MYSQL).
+		Table("users").
+		Where(Where{Column: "id", Operator: "=", Values: []any{7}}).
+		UpdateAnyWithParams(map[string]any{"active": false})
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	expected = "UPDATE `users` SET `active`=? WHERE `id` = ?;"
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+
+	if len(params) != 2 || params[0] != false || params[1] != 7 {
+		t.Fatal("Unexpected params: ", params)
+	}
+}
+
+func TestBuilderAnySqliteExec(t *testing.T) {
+	db, err := initSqliteDB("test_builder_any.db")
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	defer db.Close()
+
+	_, err = db.Exec(NewBuilder(DIALECT_SQLITE).Table("files").
+		Column(Column{Name: "id", Type: COLUMN_TYPE_INTEGER, PrimaryKey: true}).
+		Column(Column{Name: "active", Type: COLUMN_TYPE_INTEGER}).
+		Column(Column{Name: "content", Type: COLUMN_TYPE_BLOB}).
+		Column(Column{Name: "created_at", Type: COLUMN_TYPE_DATETIME}).
+		Column(Column{Name: "deleted_at", Type: COLUMN_TYPE_DATETIME, Nullable: true}).
+		Create())
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	_, err = db.Exec(NewBuilder(DIALECT_SQLITE).Table("files").InsertAny(map[string]any{
+		"id":         1,
+		"active":     true,
+		"content":    []byte("hello"),
+		"created_at": time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		"deleted_at": nil,
+	}))
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	rows, err := db.SelectToMapString(NewBuilder(DIALECT_SQLITE).
+		Table("files").
+		Where(Where{Raw: `date("created_at") = '2024-01-02'`}).
+		Where(Where{Column: "active", Operator: "=", Values: []any{true}}).
+		Where(Where{Column: "deleted_at", Operator: "=", Values: []any{nil}}).
+		Select([]string{"id", "content"}))
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	if len(rows) != 1 || rows[0]["content"] != "hello" {
+		t.Fatal("Unexpected rows: ", rows)
+	}
+}
@@ -0,0 +1,108 @@
+package sb
+
+// SelectColumns adds columns to the select list,
+// before the columns passed to Select
+func (b *Builder) SelectColumns(columns ...string) BuilderInterface {
+	b = b.mutable()
+	b.sqlSelectColumns = append(b.sqlSelectColumns, columns...)
+	return b
+}
+
+// Build converts the select query to SQL, with the values as bound
+// parameters (like SelectWithParams). Instead of panicking, it returns
+// the errors of the query, which wrap ErrNoTable, ErrUnsupportedDialect,
+// ErrUnsupportedFeature or ErrInvalidQuery. The other statements return
+// the errors with their *WithParams and *Sql methods, i.e. CreateSql
+//
+//	Example:
+//	sql, params, err := NewBuilder(DIALECT_POSTGRES).
+//		Table("users").
+//		SelectColumns("id", "name").
+//		Where(Where{Column: "id", Operator: "=", Value: "1"}).
+//		Build()
+//	// sql: SELECT "id", "name" FROM "users" WHERE "id" = $1;
+//	// params: []any{"1"}
+func (b *Builder) Build() (sql string, params []any, err error) {
+	defer recoverError(&err)
+
+	if b.err != nil {
+		return "", nil, b.err
+	}
+
+	if b.sqlTableName == "" && b.sqlFromSubquery == nil {
+		return "", nil, errNoTable("Build")
+	}
+
+	sql, params = b.withParams(func(b *Builder) string {
+		return b.Select([]string{})
+	})
+
+	return sql, params, nil
+}
+
+// CreateSql works like Create, but returns the errors instead of panicking
+func (b *Builder) CreateSql() (sqlString string, err error) {
+	defer recoverError(&err)
+	return b.Create(), nil
+}
+
+// CreateIfNotExistsSql works like CreateIfNotExists,
+// but returns the errors instead of panicking
+func (b *Builder) CreateIfNotExistsSql() (sqlString string, err error) {
+	defer recoverError(&err)
+	return b.CreateIfNotExists(), nil
+}
+
+// CreateIndexSql works like CreateIndex, but returns the errors instead of panicking
+func (b *Builder) CreateIndexSql(indexName string, columnName ...string) (sqlString string, err error) {
+	defer recoverError(&err)
+	return b.CreateIndex(indexName, columnName...), nil
+}
+
+// DeleteSql works like Delete, but returns the errors instead of panicking
+func (b *Builder) DeleteSql() (sqlString string, err error) {
+	defer recoverError(&err)
+	return b.Delete(), nil
+}
+
+// DropSql works like Drop, but returns the errors instead of panicking
+func (b *Builder) DropSql() (sqlString string, err error) {
+	defer recoverError(&err)
+	return b.Drop(), nil
+}
+
+// DropIfExistsSql works like DropIfExists, but returns the errors instead of panicking
+func (b *Builder) DropIfExistsSql() (sqlString string, err error) {
+	defer recoverError(&err)
+	return b.DropIfExists(), nil
+}
+
+// InsertSql works like Insert, but returns the errors instead of panicking
+func (b *Builder) InsertSql(columnValuesMap map[string]string) (sqlString string, err error) {
+	defer recoverError(&err)
+	return b.Insert(columnValuesMap), nil
+}
+
+// SelectSql works like Select, but returns the errors instead of panicking
+func (b *Builder) SelectSql(columns []string) (sqlString string, err error) {
+	defer recoverError(&err)
+	return b.Select(columns), nil
+}
+
+// TruncateSql works like Truncate, but returns the errors instead of panicking
+func (b *Builder) TruncateSql(options ...TruncateOptions) (sqlString string, err error) {
+	defer recoverError(&err)
+	return b.Truncate(options...), nil
+}
+
+// UpdateSql works like Update, but returns the errors instead of panicking
+func (b *Builder) UpdateSql(columnValues map[string]string) (sqlString string, err error) {
+	defer recoverError(&err)
+	return b.Update(columnValues), nil
+}
+
+// UpsertSql works like Upsert, but returns the errors instead of panicking
+func (b *Builder) UpsertSql(columnValuesMap map[string]string, upsert Upsert) (sqlString string, err error) {
+	defer recoverError(&err)
+	return b.Upsert(columnValuesMap, upsert), nil
+}
@@ -0,0 +1,139 @@
+package sb
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/samber/lo"
+)
+
+// Clone returns an independent copy of the builder, so a base query
+// can be extended without changing it
+//
+//	Example:
+//	activeUsers := NewBuilder(DIALECT_SQLITE).
+//		Table("users").
+//		Where(Where{Column: "status", Operator: "=", Value: "active"})
+//
+//	// SELECT * FROM "users" WHERE "status" = 'active' AND "role" = 'admin';
+//	activeUsers.Clone().
+//		Where(Where{Column: "role", Operator: "=", Value: "admin"}).
+//		Select([]string{})
+func (b *Builder) Clone() BuilderInterface {
+	return b.clone()
+}
+
+// Immutable returns a copy of the builder in copy-on-write mode, where
+// every method changing the query returns a changed copy, and the builder
+// itself is never changed. An immutable base query can be shared between
+// requests and goroutines, and branched safely
+//
+//	Example:
+//	activeUsers := NewBuilder(DIALECT_SQLITE).
+//		Table("users").
+//		Where(Where{Column: "status", Operator: "=", Value: "active"}).
+//		Immutable()
+//
+//	// SELECT * FROM "users" WHERE "status" = 'active' AND "role" = 'admin';
+//	admins := activeUsers.Where(Where{Column: "role", Operator: "=", Value: "admin"}).Select([]string{})
+//
+//	// SELECT * FROM "users" WHERE "status" = 'active';
+//	all := activeUsers.Select([]string{})
+func (b *Builder) Immutable() BuilderInterface {
+	clone := b.clone()
+	clone.immutable = true
+	return clone
+}
+
+// mutable returns the builder to apply a change to,
+// a copy in copy-on-write mode, or the builder itself
+func (b *Builder) mutable() *Builder {
+	if b.immutable {
+		return b.clone()
+	}
+
+	return b
+}
+
+// clone copies the builder with its own slices, so appending to the copy
+// does not change the builder. The subquery builders are cloned as well,
+// so changing a subquery of the copy does not change the builder
+func (b *Builder) clone() *Builder {
+	clone := *b
+
+	clone.sql = maps.Clone(b.sql)
+	clone.sqlAllowedColumns = slices.Clone(b.sqlAllowedColumns)
+	clone.sqlColumns = slices.Clone(b.sqlColumns)
+	clone.sqlDistinctOn = slices.Clone(b.sqlDistinctOn)
+	clone.sqlForeignKeys = slices.Clone(b.sqlForeignKeys)
+	clone.sqlGroupBy = slices.Clone(b.sqlGroupBy)
+	clone.sqlHaving = cloneWheres(b.sqlHaving)
+	clone.sqlJoin = slices.Clone(b.sqlJoin)
+	clone.sqlOrderBy = slices.Clone(b.sqlOrderBy)
+	clone.sqlReturning = slices.Clone(b.sqlReturning)
+	clone.sqlSelectColumns = slices.Clone(b.sqlSelectColumns)
+	clone.sqlSelectExpressions = slices.Clone(b.sqlSelectExpressions)
+	clone.sqlViewColumns = slices.Clone(b.sqlViewColumns)
+	clone.sqlWhere = cloneWheres(b.sqlWhere)
+
+	clone.sqlCompound = lo.Map(b.sqlCompound, func(compound compoundQuery, _ int) compoundQuery {
+		compound.query = cloneSubquery(compound.query)
+		return compound
+	})
+
+	clone.sqlSelectSubqueries = lo.Map(b.sqlSelectSubqueries, func(subquery Subquery, _ int) Subquery {
+		return cloneSubquery(subquery)
+	})
+
+	clone.sqlWith = lo.Map(b.sqlWith, func(cte commonTableExpression, _ int) commonTableExpression {
+		cte.query = cloneSubquery(cte.query)
+		if cte.recursive != nil {
+			recursive := cloneSubquery(*cte.recursive)
+			cte.recursive = &recursive
+		}
+		return cte
+	})
+
+	if b.sqlFromSubquery != nil {
+		fromSubquery := cloneSubquery(*b.sqlFromSubquery)
+		clone.sqlFromSubquery = &fromSubquery
+	}
+
+	if b.sqlLock != nil {
+		lock := *b.sqlLock
+		clone.sqlLock = &lock
+	}
+
+	return &clone
+}
+
+// cloneSubquery copies a subquery, with a clone of its builder
+func cloneSubquery(subquery Subquery) Subquery {
+	if subquery.Query != nil {
+		subquery.Query = subquery.Query.Clone()
+	}
+
+	subquery.Columns = slices.Clone(subquery.Columns)
+
+	return subquery
+}
+
+// cloneWheres copies the where conditions, with
+// their subqueries and nested conditions
+func cloneWheres(wheres []Where) []Where {
+	if wheres == nil {
+		return nil
+	}
+
+	return lo.Map(wheres, func(where Where, _ int) Where {
+		if where.Subquery != nil {
+			subquery := cloneSubquery(*where.Subquery)
+			where.Subquery = &subquery
+		}
+
+		where.Values = slices.Clone(where.Values)
+		where.Children = cloneWheres(where.Children)
+
+		return where
+	})
+}
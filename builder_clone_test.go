@@ -0,0 +1,163 @@
+package sb
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestBuilderClone(t *testing.T) {
+	base := NewBuilder(DIALECT_SQLITE).
+		Table("users").
+		Where(Where{Column: "status", Operator: "=", Value: "active"})
+
+	admins := base.Clone().
+		Where(Where{Column: "role", Operator: "=", Value: "admin"}).
+		OrderBy("name", ASC).
+		Limit(10).
+		Select([]string{"id"})
+
+	expected := `SELECT "id" FROM "users" WHERE "status" = 'active' AND "role" = 'admin' ORDER BY "name" ASC LIMIT 10;`
+	if admins != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", admins)
+	}
+
+	all := base.Select([]string{"id"})
+
+	expected = `SELECT "id" FROM "users" WHERE "status" = 'active';`
+	if all != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", all)
+	}
+}
+
+func TestBuilderCloneSharedBackingArray(t *testing.T) {
+	// Appending to slices with spare capacity must not leak between the clones
+	base := NewBuilder(DIALECT_SQLITE).Table("users")
+	base.Where(Where{Column: "a", Operator: "=", Value: "1"})
+	base.Where(Where{Column: "b", Operator: "=", Value: "2"})
+	base.Where(Where{Column: "c", Operator: "=", Value: "3"})
+
+	first := base.Clone().Where(Where{Column: "d", Operator: "=", Value: "4"})
+	second := base.Clone().Where(Where{Column: "e", Operator: "=", Value: "5"})
+
+	expected := `SELECT * FROM "users" WHERE "a" = '1' AND "b" = '2' AND "c" = '3' AND "d" = '4';`
+	if sql := first.Select([]string{}); sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+
+	expected = `SELECT * FROM "users" WHERE "a" = '1' AND "b" = '2' AND "c" = '3' AND "e" = '5';`
+	if sql := second.Select([]string{}); sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+}
+
+func TestBuilderCloneSubqueries(t *testing.T) {
+	orders := NewBuilder(DIALECT_SQLITE).Table("orders")
+	paid := NewBuilder(DIALECT_SQLITE).Table("orders")
+	archive := NewBuilder(DIALECT_SQLITE).Table("archive")
+
+	base := NewBuilder(DIALECT_SQLITE).
+		With("paid", paid).
+		Table("users").
+		Where(Where{Column: "id", Operator: OPERATOR_IN, Subquery: &Subquery{Query: orders, Columns: []string{"user_id"}}}).
+		Union(Subquery{Query: archive, Columns: []string{"id"}})
+
+	clone := base.Clone()
+
+	orders.Where(Where{Column: "total", Operator: ">", Value: "100"})
+	paid.Where(Where{Column: "status", Operator: "=", Value: "paid"})
+	archive.Where(Where{Column: "deleted", Operator: "=", Value: "1"})
+
+	expected := `WITH "paid" AS (SELECT * FROM "orders") SELECT "id" FROM "users" WHERE "id" IN (SELECT "user_id" FROM "orders") UNION SELECT "id" FROM "archive";`
+	if sql := clone.Select([]string{"id"}); sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+
+	expected = `WITH "paid" AS (SELECT * FROM "orders" WHERE "status" = 'paid') SELECT "id" FROM "users" WHERE "id" IN (SELECT "user_id" FROM "orders" WHERE "total" > '100') UNION SELECT "id" FROM "archive" WHERE "deleted" = '1';`
+	if sql := base.Select([]string{"id"}); sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+}
+
+func TestBuilderImmutable(t *testing.T) {
+	base := NewBuilder(DIALECT_SQLITE).
+		Table("users").
+		Where(Where{Column: "status", Operator: "=", Value: "active"}).
+		Immutable()
+
+	admins := base.Where(Where{Column: "role", Operator: "=", Value: "admin"})
+	editors := base.Where(Where{Column: "role", Operator: "=", Value: "editor"}).OrderBy("name", DESC).Limit(5)
+
+	expected := `SELECT * FROM "users" WHERE "status" = 'active';`
+	if sql := base.Select([]string{}); sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+
+	expected = `SELECT * FROM "users" WHERE "status" = 'active' AND "role" = 'admin';`
+	if sql := admins.Select([]string{}); sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+
+	expected = `SELECT * FROM "users" WHERE "status" = 'active' AND "role" = 'editor' ORDER BY "name" DESC LIMIT 5;`
+	if sql := editors.Select([]string{}); sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+}
+
+func TestBuilderSelectDoesNotChangeColumns(t *testing.T) {
+	columns := []string{"id", "users.name", "COUNT(*)"}
+
+	NewBuilder(DIALECT_MYSQL).Table("users").Select(columns)
+
+	if columns[0] != "id" || columns[1] != "users.name" || columns[2] != "COUNT(*)" {
+		t.Fatal("Select must not change the columns, but found: ", columns)
+	}
+}
+
+func TestBuilderImmutableConcurrent(t *testing.T) {
+	base := NewBuilder(DIALECT_POSTGRES).
+		Table("users").
+		Where(Where{Column: "status", Operator: "=", Value: "active"}).
+		Where(Where{Column: "id", Operator: OPERATOR_IN, Subquery: &Subquery{
+			Query:   NewBuilder(DIALECT_POSTGRES).Table("orders").Where(Where{Column: "total", Operator: ">", Value: "100"}),
+			Columns: []string{"user_id"},
+		}}).
+		Immutable()
+
+	columns := []string{"id", "name"}
+	expected := `SELECT "id", "name" FROM "users" WHERE "status" = $1 AND "id" IN (SELECT "user_id" FROM "orders" WHERE "total" > $2) AND "role" = $3;`
+
+	var wg sync.WaitGroup
+	errs := make(chan string, 20)
+
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			sql, params, err := base.
+				Where(Where{Column: "role", Operator: "=", Value: "admin"}).
+				SelectWithParams(columns)
+
+			if err != nil {
+				errs <- err.Error()
+				return
+			}
+
+			if sql != expected {
+				errs <- sql
+				return
+			}
+
+			if len(params) != 3 || params[0] != "active" || params[1] != "100" || params[2] != "admin" {
+				errs <- "unexpected params"
+			}
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", err)
+	}
+}
@@ -1,6 +1,9 @@
 package sb
 
 import (
+	"regexp"
+	"strings"
+
 	"github.com/samber/lo"
 )
 
@@ -8,6 +11,75 @@ type ColumnSQLGenerator interface {
 	GenerateSQL(column Column) string
 }
 
+// columnDefaultKeywords are the default expressions kept as they are
+var columnDefaultKeywords = []string{
+	"NULL",
+	"TRUE",
+	"FALSE",
+	"CURRENT_DATE",
+	"CURRENT_TIME",
+	"CURRENT_TIMESTAMP",
+	"LOCALTIME",
+	"LOCALTIMESTAMP",
+}
+
+// columnDefaultTimeFunctions are the functions allowed as defaults without
+// parentheses, as MySQL (before 8.0.13) only allows these expressions
+var columnDefaultTimeFunctions = []string{
+	"CURRENT_TIME",
+	"CURRENT_TIMESTAMP",
+	"LOCALTIME",
+	"LOCALTIMESTAMP",
+	"NOW",
+}
+
+var (
+	columnDefaultNumber   = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?$`)
+	columnDefaultFunction = regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_.]*)\s*\(.*\)$`)
+	columnDefaultLiteral  = regexp.MustCompile(`^[NnEeXx]?'`)
+)
+
+// columnDefaultToSql converts the default value of a column to a DEFAULT
+// clause. The default is a string literal, quoted for the dialect, unless
+// the column has DefaultExpression set. The expressions are kept as they are,
+// when they are keywords (NULL, CURRENT_TIMESTAMP, etc.), numbers, literals,
+// parenthesised or time functions, everything else is put in parentheses,
+// as MySQL and SQLite require for the expressions (i.e. (datetime('now')))
+func columnDefaultToSql(column Column, quoteString func(value string) (string, error)) string {
+	if column.Default == "" {
+		return ""
+	}
+
+	if !column.DefaultExpression {
+		quoted, err := quoteString(column.Default)
+		if err != nil {
+			panic(err)
+		}
+
+		return " DEFAULT " + quoted
+	}
+
+	return " DEFAULT " + columnDefaultExpressionToSql(column.Default)
+}
+
+func columnDefaultExpressionToSql(expression string) string {
+	trimmed := strings.TrimSpace(expression)
+
+	if lo.Contains(columnDefaultKeywords, strings.ToUpper(trimmed)) {
+		return trimmed
+	}
+
+	if columnDefaultNumber.MatchString(trimmed) || columnDefaultLiteral.MatchString(trimmed) || strings.HasPrefix(trimmed, "(") {
+		return trimmed
+	}
+
+	if match := columnDefaultFunction.FindStringSubmatch(trimmed); match != nil && lo.Contains(columnDefaultTimeFunctions, strings.ToUpper(match[1])) {
+		return trimmed
+	}
+
+	return "(" + trimmed + ")"
+}
+
 type MySQLColumnSQLGenerator struct{}
 
 func (g MySQLColumnSQLGenerator) GenerateSQL(column Column) string {
@@ -44,7 +116,8 @@ func (g MySQLColumnSQLGenerator) GenerateSQL(column Column) string {
 		}).
 		Else(column.Type)
 
-	sql := "`" + column.Name + "` " + columnType
+	name := MySQLDialect{}.QuoteIdentifier(column.Name)
+	sql := name + " " + columnType
 
 	// Column length
 	if columnType == "DECIMAL" {
@@ -78,12 +151,46 @@ func (g MySQLColumnSQLGenerator) GenerateSQL(column Column) string {
 	if column.Unique {
 		sql += " UNIQUE"
 	}
+
+	// Default value
+	sql += columnDefaultToSql(column, MySQLDialect{}.QuoteString)
+
 	return sql
 }
 
 type PostgreSQLColumnSQLGenerator struct{}
 
 func (g PostgreSQLColumnSQLGenerator) GenerateSQL(column Column) string {
+	name := PostgresDialect{}.QuoteIdentifier(column.Name)
+	sql := name + " " + postgresColumnTypeToSql(column)
+
+	// Auto increment
+	if column.AutoIncrement {
+		sql += " SERIAL"
+	}
+
+	// Primary key
+	if column.PrimaryKey {
+		sql += " PRIMARY KEY"
+	}
+
+	// Non Nullable / Required
+	if !column.Nullable {
+		sql += " NOT NULL"
+	}
+
+	if column.Unique {
+		sql += " UNIQUE"
+	}
+
+	// Default value
+	sql += columnDefaultToSql(column, PostgresDialect{}.QuoteString)
+
+	return sql
+}
+
+// postgresColumnTypeToSql converts the type of the column, with its length, to SQL
+func postgresColumnTypeToSql(column Column) string {
 	columnType := lo.
 		IfF(column.Type == COLUMN_TYPE_STRING, func() string {
 			return "TEXT"
@@ -114,7 +221,7 @@ func (g PostgreSQLColumnSQLGenerator) GenerateSQL(column Column) string {
 		}).
 		Else(column.Type)
 
-	sql := `"` + column.Name + `" ` + columnType + ``
+	sql := columnType
 
 	// Column length
 	if columnType == "DECIMAL" {
@@ -133,24 +240,6 @@ func (g PostgreSQLColumnSQLGenerator) GenerateSQL(column Column) string {
 		}
 	}
 
-	// Auto increment
-	if column.AutoIncrement {
-		sql += " SERIAL"
-	}
-
-	// Primary key
-	if column.PrimaryKey {
-		sql += " PRIMARY KEY"
-	}
-
-	// Non Nullable / Required
-	if !column.Nullable {
-		sql += " NOT NULL"
-	}
-
-	if column.Unique {
-		sql += " UNIQUE"
-	}
 	return sql
 }
 
@@ -187,7 +276,8 @@ func (g SQLiteColumnSQLGenerator) GenerateSQL(column Column) string {
 		}).
 		Else(column.Type)
 
-	sql := `"` + column.Name + `" ` + columnType + ``
+	name := SQLiteDialect{}.QuoteIdentifier(column.Name)
+	sql := name + " " + columnType
 
 	// Column length
 	if columnType == "DECIMAL" {
@@ -222,6 +312,9 @@ func (g SQLiteColumnSQLGenerator) GenerateSQL(column Column) string {
 		sql += " UNIQUE"
 	}
 
+	// Default value
+	sql += columnDefaultToSql(column, SQLiteDialect{}.QuoteString)
+
 	return sql
 }
 
@@ -255,7 +348,7 @@ func (g MSSQLColumnSQLGenerator) GenerateSQL(column Column) string {
 		}).
 		Else(column.Type)
 
-	sql := `"` + column.Name + `" ` + columnType + ``
+	sql := MSSQLDialect{}.QuoteIdentifier(column.Name) + " " + columnType
 
 	// Column length
 	if columnType == "DECIMAL" {
@@ -276,7 +369,7 @@ func (g MSSQLColumnSQLGenerator) GenerateSQL(column Column) string {
 
 	// Auto increment
 	if column.AutoIncrement {
-		sql += " AUTOINCREMENT"
+		sql += " IDENTITY(1,1)"
 	}
 
 	// Primary key
@@ -293,5 +386,8 @@ func (g MSSQLColumnSQLGenerator) GenerateSQL(column Column) string {
 		sql += " UNIQUE"
 	}
 
+	// Default value
+	sql += columnDefaultToSql(column, MSSQLDialect{}.QuoteString)
+
 	return sql
 }
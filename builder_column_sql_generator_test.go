@@ -0,0 +1,63 @@
+package sb
+
+import (
+	"testing"
+)
+
+func TestBuilderColumnDefault(t *testing.T) {
+	tests := []struct {
+		dialect  string
+		column   Column
+		expected string
+	}{
+		{DIALECT_MYSQL, Column{Name: "status", Type: COLUMN_TYPE_STRING, Default: "active"}, "`status` VARCHAR(255) NOT NULL DEFAULT 'active'"},
+		{DIALECT_MYSQL, Column{Name: "notes", Type: COLUMN_TYPE_STRING, Default: `it's`}, "`notes` VARCHAR(255) NOT NULL DEFAULT 'it''s'"},
+		{DIALECT_MYSQL, Column{Name: "code", Type: COLUMN_TYPE_STRING, Default: "007"}, "`code` VARCHAR(255) NOT NULL DEFAULT '007'"},
+		{DIALECT_MYSQL, Column{Name: "state", Type: COLUMN_TYPE_STRING, Default: "Unknown (none)"}, "`state` VARCHAR(255) NOT NULL DEFAULT 'Unknown (none)'"},
+		{DIALECT_MYSQL, Column{Name: "city", Type: COLUMN_TYPE_STRING, Default: "'s-Hertogenbosch"}, "`city` VARCHAR(255) NOT NULL DEFAULT '''s-Hertogenbosch'"},
+		{DIALECT_MYSQL, Column{Name: "flag", Type: COLUMN_TYPE_STRING, Default: "NULL"}, "`flag` VARCHAR(255) NOT NULL DEFAULT 'NULL'"},
+		{DIALECT_MYSQL, Column{Name: "count", Type: COLUMN_TYPE_INTEGER, Default: "0", DefaultExpression: true}, "`count` BIGINT(20) NOT NULL DEFAULT 0"},
+		{DIALECT_MYSQL, Column{Name: "created_at", Type: COLUMN_TYPE_DATETIME, Default: "CURRENT_TIMESTAMP", DefaultExpression: true}, "`created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP"},
+		{DIALECT_MYSQL, Column{Name: "id", Type: COLUMN_TYPE_STRING, Default: "uuid()", DefaultExpression: true}, "`id` VARCHAR(255) NOT NULL DEFAULT (uuid())"},
+		{DIALECT_POSTGRES, Column{Name: "price", Type: COLUMN_TYPE_FLOAT, Default: "-1.5", DefaultExpression: true}, `"price" REAL NOT NULL DEFAULT -1.5`},
+		{DIALECT_POSTGRES, Column{Name: "deleted_at", Type: COLUMN_TYPE_DATETIME, Nullable: true, Default: "NULL", DefaultExpression: true}, `"deleted_at" TIMESTAMP DEFAULT NULL`},
+		{DIALECT_POSTGRES, Column{Name: "status", Type: COLUMN_TYPE_STRING, Default: "'active'::text", DefaultExpression: true}, `"status" TEXT NOT NULL DEFAULT 'active'::text`},
+		{DIALECT_POSTGRES, Column{Name: "path", Type: COLUMN_TYPE_STRING, Default: `C:\temp`}, `"path" TEXT NOT NULL DEFAULT E'C:\\temp'`},
+		{DIALECT_POSTGRES, Column{Name: "city", Type: COLUMN_TYPE_STRING, Default: "'s-Hertogenbosch"}, `"city" TEXT NOT NULL DEFAULT '''s-Hertogenbosch'`},
+		{DIALECT_SQLITE, Column{Name: "created_at", Type: COLUMN_TYPE_DATETIME, Default: "datetime('now')", DefaultExpression: true}, `"created_at" DATETIME NOT NULL DEFAULT (datetime('now'))`},
+		{DIALECT_SQLITE, Column{Name: "created_at", Type: COLUMN_TYPE_DATETIME, Default: "current_timestamp", DefaultExpression: true}, `"created_at" DATETIME NOT NULL DEFAULT current_timestamp`},
+		{DIALECT_SQLITE, Column{Name: "status", Type: COLUMN_TYPE_STRING, Default: "x'y"}, `"status" TEXT NOT NULL DEFAULT 'x''y'`},
+		{DIALECT_SQLITE, Column{Name: "state", Type: COLUMN_TYPE_STRING, Default: "Unknown (none)"}, `"state" TEXT NOT NULL DEFAULT 'Unknown (none)'`},
+		{DIALECT_MSSQL, Column{Name: "status", Type: COLUMN_TYPE_STRING, Length: 20, Default: "active"}, `[status] NVARCHAR(20) NOT NULL DEFAULT N'active'`},
+		{DIALECT_MSSQL, Column{Name: "created_at", Type: COLUMN_TYPE_DATETIME, Default: "getdate()", DefaultExpression: true}, `[created_at] DATETIME2 NOT NULL DEFAULT (getdate())`},
+	}
+
+	for _, test := range tests {
+		sql := NewBuilder(test.dialect).columnSQLGenerator.GenerateSQL(test.column)
+		if sql != test.expected {
+			t.Fatal("Expected:\n", test.expected, "\nbut found:\n", sql)
+		}
+	}
+}
+
+func TestBuilderColumnDefaultTableColumnChange(t *testing.T) {
+	sql, err := NewBuilder(DIALECT_MSSQL).TableColumnChange("users", Column{Name: "status", Type: COLUMN_TYPE_STRING, Length: 20, Default: "active"})
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	expected := `ALTER TABLE [users] ALTER COLUMN [status] NVARCHAR(20) NOT NULL; ALTER TABLE [users] ADD DEFAULT N'active' FOR [status];`
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+
+	sql, err = NewBuilder(DIALECT_SQLITE).TableColumnAdd("users", Column{Name: "status", Type: COLUMN_TYPE_STRING, Default: "active"})
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	expected = `ALTER TABLE "users" ADD COLUMN "status" TEXT NOT NULL DEFAULT 'active';`
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+}
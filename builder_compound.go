@@ -0,0 +1,83 @@
+package sb
+
+// compoundQuery is a select combined with the main select
+// by a set operator (UNION, UNION ALL, INTERSECT, EXCEPT)
+type compoundQuery struct {
+	operator string
+	query    Subquery
+}
+
+// Union combines the select with another one, removing duplicates.
+// The OrderBy, Limit and Offset of the builder apply to the combined result
+//
+//	Example:
+//	// SELECT "name" FROM "customers" UNION SELECT "name" FROM "suppliers" ORDER BY "name" ASC;
+//	NewBuilder(DIALECT_SQLITE).
+//		Table("customers").
+//		Union(Subquery{Query: NewBuilder(DIALECT_SQLITE).Table("suppliers"), Columns: []string{"name"}}).
+//		OrderBy("name", ASC).
+//		Select([]string{"name"})
+func (b *Builder) Union(query Subquery) BuilderInterface {
+	return b.compoundAdd("UNION", query)
+}
+
+// UnionAll combines the select with another one, keeping duplicates
+func (b *Builder) UnionAll(query Subquery) BuilderInterface {
+	return b.compoundAdd("UNION ALL", query)
+}
+
+// Intersect keeps the rows returned by both the select and the other one
+func (b *Builder) Intersect(query Subquery) BuilderInterface {
+	return b.compoundAdd("INTERSECT", query)
+}
+
+// Except keeps the rows returned by the select but not by the other one
+func (b *Builder) Except(query Subquery) BuilderInterface {
+	return b.compoundAdd("EXCEPT", query)
+}
+
+func (b *Builder) compoundAdd(operator string, query Subquery) BuilderInterface {
+	b = b.mutable()
+	b.sqlCompound = append(b.sqlCompound, compoundQuery{
+		operator: operator,
+		query:    query,
+	})
+
+	return b
+}
+
+// compoundToSql combines the main select with the compound selects.
+// The selects are parenthesised, except in SQLite which does not allow
+// it, there a select with its own ordering or paging is wrapped in
+// a SELECT * FROM (...) instead
+func (b *Builder) compoundToSql(sql string, compounds []compoundQuery) string {
+	if b.syntax() != DIALECT_SQLITE {
+		sql = "(" + sql + ")"
+	}
+
+	for _, compound := range compounds {
+		part := b.subquerySelectToSql(compound.query)
+
+		if b.syntax() != DIALECT_SQLITE {
+			part = "(" + part + ")"
+		} else if compoundNeedsWrapping(compound.query) {
+			part = "SELECT * FROM (" + part + ")"
+		}
+
+		sql += " " + compound.operator + " " + part
+	}
+
+	return sql
+}
+
+// compoundNeedsWrapping checks if the select cannot be used
+// directly as a part of a compound select in SQLite
+func compoundNeedsWrapping(subquery Subquery) bool {
+	query, ok := subquery.Query.(*Builder)
+
+	if !ok || query == nil {
+		return false
+	}
+
+	return len(query.sqlOrderBy) > 0 || query.sqlLimit > 0 || query.sqlOffset > 0 || len(query.sqlCompound) > 0 || len(query.sqlWith) > 0
+}
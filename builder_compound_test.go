@@ -0,0 +1,170 @@
+package sb
+
+import (
+	"testing"
+)
+
+func TestBuilderCompound(t *testing.T) {
+	tests := []struct {
+		dialect  string
+		expected string
+	}{
+		{DIALECT_MYSQL, "(SELECT `name` FROM `customers` WHERE `active` = '1') UNION (SELECT `name` FROM `suppliers`) ORDER BY `name` ASC LIMIT 10 OFFSET 20;"},
+		{DIALECT_POSTGRES, `(SELECT "name" FROM "customers" WHERE "active" = '1') UNION (SELECT "name" FROM "suppliers") ORDER BY "name" ASC LIMIT 10 OFFSET 20;`},
+		{DIALECT_SQLITE, `SELECT "name" FROM "customers" WHERE "active" = '1' UNION SELECT "name" FROM "suppliers" ORDER BY "name" ASC LIMIT 10 OFFSET 20;`},
+	}
+
+	for _, test := range tests {
+		sql := NewBuilder(test.dialect).
+			Table("customers").
+			Where(Where{Column: "active", Operator: "=", Value: "1"}).
+			Union(Subquery{Query: NewBuilder(test.dialect).Table("suppliers"), Columns: []string{"name"}}).
+			OrderBy("name", ASC).
+			Limit(10).
+			Offset(20).
+			Select([]string{"name"})
+
+		if sql != test.expected {
+			t.Fatal("Expected:\n", test.expected, "\nbut found:\n", sql)
+		}
+	}
+}
+
+func TestBuilderCompoundOperators(t *testing.T) {
+	sql := NewBuilder(DIALECT_POSTGRES).
+		Table("a").
+		UnionAll(Subquery{Query: NewBuilder(DIALECT_POSTGRES).Table("b"), Columns: []string{"id"}}).
+		Intersect(Subquery{Query: NewBuilder(DIALECT_POSTGRES).Table("c"), Columns: []string{"id"}}).
+		Except(Subquery{Query: NewBuilder(DIALECT_POSTGRES).Table("d"), Columns: []string{"id"}}).
+		Select([]string{"id"})
+
+	expected := `(SELECT "id" FROM "a") UNION ALL (SELECT "id" FROM "b") INTERSECT (SELECT "id" FROM "c") EXCEPT (SELECT "id" FROM "d");`
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+}
+
+func TestBuilderCompoundSqliteWrapsOrderedPart(t *testing.T) {
+	sql := NewBuilder(DIALECT_SQLITE).
+		Table("a").
+		Union(Subquery{
+			Query:   NewBuilder(DIALECT_SQLITE).Table("b").OrderBy("id", DESC).Limit(5),
+			Columns: []string{"id"},
+		}).
+		Select([]string{"id"})
+
+	expected := `SELECT "id" FROM "a" UNION SELECT * FROM (SELECT "id" FROM "b" ORDER BY "id" DESC LIMIT 5);`
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+}
+
+func TestBuilderCompoundWithParams(t *testing.T) {
+	sql, params, err := NewBuilder(DIALECT_POSTGRES).
+		Table("customers").
+		Where(Where{Column: "country", Operator: "=", Value: "UK"}).
+		Union(Subquery{
+			Query:   NewBuilder(DIALECT_POSTGRES).Table("suppliers").Where(Where{Column: "country", Operator: "=", Value: "US"}),
+			Columns: []string{"name"},
+		}).
+		SelectWithParams([]string{"name"})
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	expected := `(SELECT "name" FROM "customers" WHERE "country" = $1) UNION (SELECT "name" FROM "suppliers" WHERE "country" = $2);`
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+
+	if len(params) != 2 || params[0] != "UK" || params[1] != "US" {
+		t.Fatal("Unexpected params: ", params)
+	}
+}
+
+func TestBuilderLimitOffsetMssql(t *testing.T) {
+	tests := []struct {
+		limit      int64
+		offset     int64
+		hasOrderBy bool
+		expected   string
+	}{
+		{0, 0, false, ""},
+		{10, 0, true, " OFFSET 0 ROWS FETCH NEXT 10 ROWS ONLY"},
+		{10, 20, false, " ORDER BY (SELECT NULL) OFFSET 20 ROWS FETCH NEXT 10 ROWS ONLY"},
+		{0, 20, true, " OFFSET 20 ROWS"},
+	}
+
+	for _, test := range tests {
+		b := NewBuilder(DIALECT_MSSQL).Table("users").Limit(test.limit).Offset(test.offset).(*Builder)
+
+		sql := b.limitOffsetToSql(test.hasOrderBy)
+		if sql != test.expected {
+			t.Fatal("Expected:\n", test.expected, "\nbut found:\n", sql)
+		}
+	}
+}
+
+func TestBuilderCompoundSqliteExec(t *testing.T) {
+	db, err := initSqliteDB("test_builder_compound.db")
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	defer db.Close()
+
+	statements := []string{
+		NewBuilder(DIALECT_SQLITE).Table("customers").
+			Column(Column{Name: "name", Type: COLUMN_TYPE_STRING}).
+			Create(),
+		NewBuilder(DIALECT_SQLITE).Table("suppliers").
+			Column(Column{Name: "name", Type: COLUMN_TYPE_STRING}).
+			Create(),
+		`INSERT INTO "customers" ("name") VALUES ('Ann'), ('Bob'), ('Tom')`,
+		`INSERT INTO "suppliers" ("name") VALUES ('Bob'), ('Zoe')`,
+	}
+
+	for _, statement := range statements {
+		_, err = db.Exec(statement)
+		if err != nil {
+			t.Fatal("Error must be NIL but got: ", err.Error(), " for ", statement)
+		}
+	}
+
+	tests := []struct {
+		builder  BuilderInterface
+		expected []string
+	}{
+		{NewBuilder(DIALECT_SQLITE).Table("customers").Union(Subquery{Query: NewBuilder(DIALECT_SQLITE).Table("suppliers"), Columns: []string{"name"}}).OrderBy("name", DESC).Limit(2).Offset(1), []string{"Tom", "Bob"}},
+		{NewBuilder(DIALECT_SQLITE).Table("customers").UnionAll(Subquery{Query: NewBuilder(DIALECT_SQLITE).Table("suppliers"), Columns: []string{"name"}}).OrderBy("name", ASC), []string{"Ann", "Bob", "Bob", "Tom", "Zoe"}},
+		{NewBuilder(DIALECT_SQLITE).Table("customers").Intersect(Subquery{Query: NewBuilder(DIALECT_SQLITE).Table("suppliers"), Columns: []string{"name"}}), []string{"Bob"}},
+		{NewBuilder(DIALECT_SQLITE).Table("customers").Except(Subquery{Query: NewBuilder(DIALECT_SQLITE).Table("suppliers").OrderBy("name", ASC).Limit(1), Columns: []string{"name"}}).OrderBy("name", ASC), []string{"Ann", "Tom"}},
+	}
+
+	for _, test := range tests {
+		sql := test.builder.Select([]string{"name"})
+
+		rows, err := db.SelectToMapString(sql)
+
+		if err != nil {
+			t.Fatal("Error must be NIL but got: ", err.Error(), " for ", sql)
+		}
+
+		names := []string{}
+		for _, row := range rows {
+			names = append(names, row["name"])
+		}
+
+		if len(names) != len(test.expected) {
+			t.Fatal("Expected ", test.expected, " but got ", names, " for ", sql)
+		}
+
+		for i := range names {
+			if names[i] != test.expected[i] {
+				t.Fatal("Expected ", test.expected, " but got ", names, " for ", sql)
+			}
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package sb
+
+import (
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// Distinct removes the duplicate rows from the select results
+func (b *Builder) Distinct() BuilderInterface {
+	b = b.mutable()
+	b.sqlDistinct = true
+	return b
+}
+
+// DistinctOn keeps only the first row of each set of rows having
+// the same values in the columns (PostgreSQL only). The OrderBy
+// columns must start with the same columns
+//
+//	Example:
+//	// SELECT DISTINCT ON ("user_id") "user_id", "total" FROM "orders" ORDER BY "user_id" ASC,"created_at" DESC;
+//	NewBuilder(DIALECT_POSTGRES).
+//		Table("orders").
+//		DistinctOn("user_id").
+//		OrderBy("user_id", ASC).
+//		OrderBy("created_at", DESC).
+//		Select([]string{"user_id", "total"})
+func (b *Builder) DistinctOn(columns ...string) BuilderInterface {
+	if len(columns) == 0 {
+		return b.errorAdd(errInvalidQuery("distinct on requires at least one column"))
+	}
+
+	b = b.mutable()
+	b.sqlDistinctOn = columns
+
+	return b
+}
+
+// distinctToSql converts the distinct option to SQL,
+// including the trailing space
+func (b *Builder) distinctToSql() string {
+	if len(b.sqlDistinctOn) > 0 {
+		if !b.supports(FEATURE_DISTINCT_ON) {
+			b.fail(b.errUnsupportedFeature("distinct on"))
+		}
+
+		columns := lo.Map(b.sqlDistinctOn, func(column string, _ int) string {
+			return b.quoteAllowedColumn(column)
+		})
+		return "DISTINCT ON (" + strings.Join(columns, ", ") + ") "
+	}
+
+	if b.sqlDistinct {
+		return "DISTINCT "
+	}
+
+	return ""
+}
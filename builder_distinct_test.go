@@ -0,0 +1,89 @@
+package sb
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBuilderDistinct(t *testing.T) {
+	tests := []struct {
+		dialect  string
+		expected string
+	}{
+		{DIALECT_MYSQL, "SELECT DISTINCT `country` FROM `users` LIMIT 5;"},
+		{DIALECT_POSTGRES, `SELECT DISTINCT "country" FROM "users" LIMIT 5;`},
+		{DIALECT_SQLITE, `SELECT DISTINCT "country" FROM "users" LIMIT 5;`},
+		{DIALECT_MSSQL, `SELECT DISTINCT TOP 5 [country] FROM [users];`},
+	}
+
+	for _, test := range tests {
+		sql := NewBuilder(test.dialect).
+			Table("users").
+			Distinct().
+			Limit(5).
+			Select([]string{"country"})
+
+		if sql != test.expected {
+			t.Fatal("Expected:\n", test.expected, "\nbut found:\n", sql)
+		}
+	}
+}
+
+func TestBuilderDistinctOn(t *testing.T) {
+	sql := NewBuilder(DIALECT_POSTGRES).
+		Table("orders").
+		DistinctOn("user_id", "status").
+		OrderBy("user_id", ASC).
+		OrderBy("status", ASC).
+		OrderBy("created_at", DESC).
+		Select([]string{"user_id", "status", "total"})
+
+	expected := `SELECT DISTINCT ON ("user_id", "status") "user_id", "status", "total" FROM "orders" ORDER BY "user_id" ASC,"status" ASC,"created_at" DESC;`
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+}
+
+func TestBuilderDistinctOnUnsupported(t *testing.T) {
+	for _, dialect := range []string{DIALECT_MYSQL, DIALECT_SQLITE, DIALECT_MSSQL} {
+		_, _, err := NewBuilder(dialect).Table("orders").DistinctOn("user_id").Build()
+
+		if !errors.Is(err, ErrUnsupportedFeature) {
+			t.Fatal("Error must be ErrUnsupportedFeature for ", dialect, " but got: ", err)
+		}
+	}
+}
+
+func TestBuilderDistinctSqliteExec(t *testing.T) {
+	db, err := initSqliteDB("test_builder_distinct.db")
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	defer db.Close()
+
+	statements := []string{
+		NewBuilder(DIALECT_SQLITE).Table("users").
+			Column(Column{Name: "country", Type: COLUMN_TYPE_STRING}).
+			Create(),
+		`INSERT INTO "users" ("country") VALUES ('UK'), ('US'), ('UK')`,
+	}
+
+	for _, statement := range statements {
+		_, err = db.Exec(statement)
+		if err != nil {
+			t.Fatal("Error must be NIL but got: ", err.Error(), " for ", statement)
+		}
+	}
+
+	rows, err := db.SelectToMapString(NewBuilder(DIALECT_SQLITE).Table("users").Distinct().Select([]string{"country"}))
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	if len(rows) != 2 {
+		t.Fatal("Expected 2 rows but got: ", rows)
+	}
+}
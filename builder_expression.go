@@ -0,0 +1,161 @@
+package sb
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// Expression represents an aggregate or window function call
+// in the select list. The column identifiers are quoted for the dialect
+//
+//	Example:
+//	// SELECT "user_id", COUNT(*) AS "orders",
+//	// ROW_NUMBER() OVER (PARTITION BY "country" ORDER BY "total" DESC) AS "position" FROM "orders" ...
+//	NewBuilder(DIALECT_POSTGRES).
+//		Table("orders").
+//		SelectExpression(Expression{Function: FUNCTION_COUNT, Alias: "orders"}).
+//		SelectExpression(Expression{
+//			Function: FUNCTION_ROW_NUMBER,
+//			Window: &Window{
+//				PartitionBy: []string{"country"},
+//				OrderBy:     []OrderBy{{Column: "total", Direction: DESC}},
+//			},
+//			Alias: "position",
+//		}).
+//		Select([]string{"user_id"})
+type Expression struct {
+	// Function is the function name, one of the FUNCTION_* constants
+	Function string
+
+	// Columns are the column arguments of the function. COUNT
+	// without columns counts all the rows, as COUNT(*)
+	Columns []string
+
+	// Values are the value arguments, after the columns,
+	// i.e. the default value of COALESCE
+	Values []any
+
+	// Distinct applies the function to the distinct values only
+	Distinct bool
+
+	// Window makes the function a window function, with the OVER clause.
+	// The ranking functions (ROW_NUMBER, RANK, DENSE_RANK) require it,
+	// an empty window is used when not set
+	Window *Window
+
+	// Alias is the name of the result column
+	Alias string
+}
+
+// Window represents the OVER clause of a window function
+type Window struct {
+	PartitionBy []string
+	OrderBy     []OrderBy
+}
+
+// SelectExpression adds an aggregate or window function to the select list
+func (b *Builder) SelectExpression(expression Expression) BuilderInterface {
+	if expression.Function == "" {
+		return b.errorAdd(errInvalidQuery("expression function is required"))
+	}
+
+	b = b.mutable()
+	b.sqlSelectExpressions = append(b.sqlSelectExpressions, expression)
+
+	return b
+}
+
+// selectExpressionsToSql converts the select expressions to SQL
+func (b *Builder) selectExpressionsToSql(expressions []Expression) string {
+	sql := lo.Map(expressions, func(expression Expression, _ int) string {
+		return b.expressionToSql(expression)
+	})
+
+	return strings.Join(sql, ", ")
+}
+
+// expressionFunctions are the functions allowed in the expressions,
+// the FUNCTION_* constants
+var expressionFunctions = []string{
+	FUNCTION_AVG,
+	FUNCTION_COALESCE,
+	FUNCTION_COUNT,
+	FUNCTION_DENSE_RANK,
+	FUNCTION_MAX,
+	FUNCTION_MIN,
+	FUNCTION_RANK,
+	FUNCTION_ROW_NUMBER,
+	FUNCTION_SUM,
+}
+
+// expressionToSql converts an expression, with its alias, to SQL.
+// Any function other than the FUNCTION_* constants fails,
+// as the function name is not quoted in the SQL
+func (b *Builder) expressionToSql(expression Expression) string {
+	function := strings.ToUpper(expression.Function)
+
+	if !lo.Contains(expressionFunctions, function) {
+		b.fail(errInvalidQuery("function " + strconv.Quote(expression.Function) + " is not supported"))
+	}
+
+	arguments := lo.Map(expression.Columns, func(column string, _ int) string {
+		return b.quoteAllowedColumn(column)
+	})
+
+	for _, value := range expression.Values {
+		arguments = append(arguments, b.quoteValueAny(value))
+	}
+
+	if function == FUNCTION_COUNT && len(arguments) == 0 {
+		arguments = []string{"*"}
+	}
+
+	distinct := lo.Ternary(expression.Distinct, "DISTINCT ", "")
+
+	sql := function + "(" + distinct + strings.Join(arguments, ", ") + ")"
+
+	window := expression.Window
+	isRanking := function == FUNCTION_ROW_NUMBER || function == FUNCTION_RANK || function == FUNCTION_DENSE_RANK
+	if window == nil && isRanking {
+		window = &Window{}
+	}
+
+	if window != nil {
+		if !b.supports(FEATURE_WINDOW_FUNCTIONS) {
+			b.fail(b.errUnsupportedFeature("window functions"))
+		}
+
+		sql += " OVER (" + b.windowToSql(*window) + ")"
+	}
+
+	if expression.Alias != "" {
+		sql += " AS " + b.quoteColumn(expression.Alias)
+	}
+
+	return sql
+}
+
+// windowToSql converts a window to SQL, without the OVER keyword
+func (b *Builder) windowToSql(window Window) string {
+	parts := []string{}
+
+	if len(window.PartitionBy) > 0 {
+		columns := lo.Map(window.PartitionBy, func(column string, _ int) string {
+			return b.quoteAllowedColumn(column)
+		})
+		parts = append(parts, "PARTITION BY "+strings.Join(columns, ", "))
+	}
+
+	if len(window.OrderBy) > 0 {
+		columns := lo.Map(window.OrderBy, func(orderBy OrderBy, _ int) string {
+			isDesc := strings.EqualFold(orderBy.Direction, "desc") || strings.EqualFold(orderBy.Direction, "descending")
+			direction := lo.Ternary(isDesc, "DESC", "ASC")
+			return b.quoteAllowedColumn(orderBy.Column) + " " + direction
+		})
+		parts = append(parts, "ORDER BY "+strings.Join(columns, ", "))
+	}
+
+	return strings.Join(parts, " ")
+}
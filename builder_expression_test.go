@@ -0,0 +1,169 @@
+package sb
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBuilderSelectExpression(t *testing.T) {
+	tests := []struct {
+		dialect  string
+		expected string
+	}{
+		{DIALECT_MYSQL, "SELECT `country`, COUNT(*) AS `users`, SUM(DISTINCT `o`.`total`) AS `total`, ROW_NUMBER() OVER (PARTITION BY `country` ORDER BY `created_at` DESC) AS `position` FROM `users` GROUP BY `country`;"},
+		{DIALECT_POSTGRES, `SELECT "country", COUNT(*) AS "users", SUM(DISTINCT "o"."total") AS "total", ROW_NUMBER() OVER (PARTITION BY "country" ORDER BY "created_at" DESC) AS "position" FROM "users" GROUP BY "country";`},
+		{DIALECT_SQLITE, `SELECT "country", COUNT(*) AS "users", SUM(DISTINCT "o"."total") AS "total", ROW_NUMBER() OVER (PARTITION BY "country" ORDER BY "created_at" DESC) AS "position" FROM "users" GROUP BY "country";`},
+		{DIALECT_MSSQL, `SELECT [country], COUNT(*) AS [users], SUM(DISTINCT [o].[total]) AS [total], ROW_NUMBER() OVER (PARTITION BY [country] ORDER BY [created_at] DESC) AS [position] FROM [users] GROUP BY [country];`},
+	}
+
+	for _, test := range tests {
+		sql := NewBuilder(test.dialect).
+			Table("users").
+			SelectExpression(Expression{Function: FUNCTION_COUNT, Alias: "users"}).
+			SelectExpression(Expression{Function: FUNCTION_SUM, Columns: []string{"o.total"}, Distinct: true, Alias: "total"}).
+			SelectExpression(Expression{
+				Function: FUNCTION_ROW_NUMBER,
+				Window: &Window{
+					PartitionBy: []string{"country"},
+					OrderBy:     []OrderBy{{Column: "created_at", Direction: DESC}},
+				},
+				Alias: "position",
+			}).
+			GroupBy(GroupBy{Column: "country"}).
+			Select([]string{"country"})
+
+		if sql != test.expected {
+			t.Fatal("Expected:\n", test.expected, "\nbut found:\n", sql)
+		}
+	}
+}
+
+func TestBuilderSelectExpressionFunctions(t *testing.T) {
+	tests := []struct {
+		expression Expression
+		expected   string
+	}{
+		{Expression{Function: FUNCTION_AVG, Columns: []string{"price"}}, `AVG("price")`},
+		{Expression{Function: FUNCTION_MIN, Columns: []string{"price"}, Alias: "min_price"}, `MIN("price") AS "min_price"`},
+		{Expression{Function: FUNCTION_MAX, Columns: []string{"price"}, Window: &Window{}}, `MAX("price") OVER ()`},
+		{Expression{Function: FUNCTION_COUNT, Columns: []string{"email"}, Distinct: true}, `COUNT(DISTINCT "email")`},
+		{Expression{Function: FUNCTION_COALESCE, Columns: []string{"nickname", "name"}, Values: []any{"unknown"}}, `COALESCE("nickname", "name", 'unknown')`},
+		{Expression{Function: FUNCTION_RANK}, `RANK() OVER ()`},
+		{Expression{Function: "dense_rank", Window: &Window{OrderBy: []OrderBy{{Column: "score"}}}}, `DENSE_RANK() OVER (ORDER BY "score" ASC)`},
+		{Expression{Function: FUNCTION_SUM, Columns: []string{"total"}, Window: &Window{PartitionBy: []string{"user_id", "year"}}}, `SUM("total") OVER (PARTITION BY "user_id", "year")`},
+	}
+
+	for _, test := range tests {
+		sql := NewBuilder(DIALECT_SQLITE).
+			Table("orders").
+			SelectExpression(test.expression).
+			Select([]string{})
+
+		expected := `SELECT ` + test.expected + ` FROM "orders";`
+		if sql != expected {
+			t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+		}
+	}
+}
+
+func TestBuilderSelectExpressionFunctionInvalid(t *testing.T) {
+	functions := []string{"SLEEP", "COUNT(*) FROM users; --", "pg_sleep"}
+
+	for _, function := range functions {
+		_, _, err := NewBuilder(DIALECT_POSTGRES).
+			Table("orders").
+			SelectExpression(Expression{Function: function}).
+			Build()
+
+		if !errors.Is(err, ErrInvalidQuery) {
+			t.Fatal("Expected ErrInvalidQuery for function ", function, " but found: ", err)
+		}
+
+		_, _, err = NewBuilder(DIALECT_POSTGRES).
+			Table("orders").
+			GroupBy(GroupBy{Column: "user_id"}).
+			Having(Where{Expression: &Expression{Function: function}, Operator: ">", Values: []any{1}}).
+			SelectWithParams([]string{"user_id"})
+
+		if !errors.Is(err, ErrInvalidQuery) {
+			t.Fatal("Expected ErrInvalidQuery for the having function ", function, " but found: ", err)
+		}
+	}
+}
+
+func TestBuilderSelectFunctionColumnQuoted(t *testing.T) {
+	sql := NewBuilder(DIALECT_MYSQL).
+		Table("users").
+		Select([]string{"COUNT(*)"})
+
+	expected := "SELECT `COUNT(*)` FROM `users`;"
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+}
+
+func TestBuilderSelectExpressionWithParams(t *testing.T) {
+	sql, params, err := NewBuilder(DIALECT_POSTGRES).
+		Table("users").
+		SelectExpression(Expression{Function: FUNCTION_COALESCE, Columns: []string{"nickname"}, Values: []any{"unknown"}, Alias: "nickname"}).
+		Where(Where{Column: "status", Operator: "=", Value: "active"}).
+		SelectWithParams([]string{"id"})
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	expected := `SELECT "id", COALESCE("nickname", $1) AS "nickname" FROM "users" WHERE "status" = $2;`
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+
+	if len(params) != 2 || params[0] != "unknown" || params[1] != "active" {
+		t.Fatal("Unexpected params: ", params)
+	}
+}
+
+func TestBuilderSelectExpressionSqliteExec(t *testing.T) {
+	db, err := initSqliteDB("test_builder_expression.db")
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	defer db.Close()
+
+	statements := []string{
+		NewBuilder(DIALECT_SQLITE).Table("orders").
+			Column(Column{Name: "user_id", Type: COLUMN_TYPE_STRING}).
+			Column(Column{Name: "total", Type: COLUMN_TYPE_INTEGER}).
+			Create(),
+		`INSERT INTO "orders" ("user_id", "total") VALUES ('1', 10), ('1', 30), ('2', 20)`,
+	}
+
+	for _, statement := range statements {
+		_, err = db.Exec(statement)
+		if err != nil {
+			t.Fatal("Error must be NIL but got: ", err.Error(), " for ", statement)
+		}
+	}
+
+	rows, err := db.SelectToMapString(NewBuilder(DIALECT_SQLITE).
+		Table("orders").
+		SelectExpression(Expression{
+			Function: FUNCTION_ROW_NUMBER,
+			Window:   &Window{PartitionBy: []string{"user_id"}, OrderBy: []OrderBy{{Column: "total", Direction: DESC}}},
+			Alias:    "position",
+		}).
+		SelectExpression(Expression{Function: FUNCTION_SUM, Columns: []string{"total"}, Window: &Window{PartitionBy: []string{"user_id"}}, Alias: "user_total"}).
+		OrderBy("user_id", ASC).
+		OrderBy("position", ASC).
+		Select([]string{"user_id", "total"}))
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	if len(rows) != 3 || rows[0]["total"] != "30" || rows[0]["position"] != "1" || rows[1]["user_total"] != "40" || rows[2]["position"] != "1" {
+		t.Fatal("Unexpected rows: ", rows)
+	}
+}
@@ -0,0 +1,263 @@
+package sb
+
+import (
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// ForeignKey represents a foreign key constraint of a table
+//
+//	Example:
+//	// CREATE TABLE "orders"("id" TEXT NOT NULL, "user_id" TEXT NOT NULL,
+//	// CONSTRAINT "fk_orders_users" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE);
+//	NewBuilder(DIALECT_SQLITE).
+//		Table("orders").
+//		Column(Column{Name: "id", Type: COLUMN_TYPE_STRING}).
+//		Column(Column{Name: "user_id", Type: COLUMN_TYPE_STRING}).
+//		ForeignKey(ForeignKey{
+//			Name:              "fk_orders_users",
+//			Columns:           []string{"user_id"},
+//			ReferencedTable:   "users",
+//			ReferencedColumns: []string{"id"},
+//			OnDelete:          FOREIGN_KEY_ACTION_CASCADE,
+//		}).
+//		Create()
+type ForeignKey struct {
+	// Name is the name of the constraint, optional, but
+	// required to drop the constraint with TableForeignKeyDrop
+	Name string
+
+	// Columns are the columns of the table referencing the other table
+	Columns []string
+
+	// ReferencedTable is the table referenced
+	ReferencedTable string
+
+	// ReferencedColumns are the columns of the referenced table,
+	// in the order of the columns referencing them
+	ReferencedColumns []string
+
+	// OnDelete is the action on deleting the referenced
+	// row, one of the FOREIGN_KEY_ACTION_* constants
+	OnDelete string
+
+	// OnUpdate is the action on updating the referenced
+	// row, one of the FOREIGN_KEY_ACTION_* constants
+	OnUpdate string
+}
+
+// ForeignKey adds a foreign key constraint to the table created
+func (b *Builder) ForeignKey(foreignKey ForeignKey) BuilderInterface {
+	if err := b.validateForeignKey(foreignKey); err != nil {
+		return b.errorAdd(err)
+	}
+
+	b = b.mutable()
+	b.sqlForeignKeys = append(b.sqlForeignKeys, foreignKey)
+
+	return b
+}
+
+// TableForeignKeyAdd adds a foreign key constraint to an existing table.
+// SQLite can not add constraints to a table, the table must be rebuilt
+// there, which the TableForeignKeyAdd function does on the database
+//
+//	Example:
+//	sql, err := NewBuilder(DIALECT_POSTGRES).TableForeignKeyAdd("orders", ForeignKey{
+//		Name:              "fk_orders_users",
+//		Columns:           []string{"user_id"},
+//		ReferencedTable:   "users",
+//		ReferencedColumns: []string{"id"},
+//	})
+//	// ALTER TABLE "orders" ADD CONSTRAINT "fk_orders_users" FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+func (b *Builder) TableForeignKeyAdd(tableName string, foreignKey ForeignKey) (sql string, err error) {
+	defer recoverError(&err)
+
+	if b.err != nil {
+		return "", b.err
+	}
+
+	if err := b.validateForeignKey(foreignKey); err != nil {
+		return "", err
+	}
+
+	switch b.syntax() {
+	case DIALECT_MSSQL, DIALECT_MYSQL, DIALECT_POSTGRES:
+		return "ALTER TABLE " + b.quoteTable(tableName) + " ADD " + b.foreignKeyToSql(foreignKey) + ";", nil
+	}
+
+	return "", b.errUnsupportedFeature("adding a foreign key (the TableForeignKeyAdd function rebuilds the table)")
+}
+
+// TableForeignKeyDrop drops a foreign key constraint, by its name, from a table.
+// SQLite can not drop constraints from a table, the table must be rebuilt
+// there, which the TableForeignKeyDrop function does on the database
+//
+//	Example:
+//	sql, err := NewBuilder(DIALECT_MYSQL).TableForeignKeyDrop("orders", "fk_orders_users")
+//	// ALTER TABLE `orders` DROP FOREIGN KEY `fk_orders_users`;
+func (b *Builder) TableForeignKeyDrop(tableName string, foreignKeyName string) (sql string, err error) {
+	defer recoverError(&err)
+
+	if b.err != nil {
+		return "", b.err
+	}
+
+	if foreignKeyName == "" {
+		return "", errInvalidQuery("in method TableForeignKeyDrop() no foreign key name specified")
+	}
+
+	switch b.syntax() {
+	case DIALECT_MYSQL:
+		return "ALTER TABLE " + b.quoteTable(tableName) + " DROP FOREIGN KEY " + b.quote(foreignKeyName, "column") + ";", nil
+	case DIALECT_MSSQL, DIALECT_POSTGRES:
+		return "ALTER TABLE " + b.quoteTable(tableName) + " DROP CONSTRAINT " + b.quote(foreignKeyName, "column") + ";", nil
+	}
+
+	return "", b.errUnsupportedFeature("dropping a foreign key (the TableForeignKeyDrop function rebuilds the table)")
+}
+
+// validateForeignKey checks the foreign key has the columns, the referenced
+// table and columns, and actions the dialect supports
+func (b *Builder) validateForeignKey(foreignKey ForeignKey) error {
+	if len(foreignKey.Columns) == 0 {
+		return errInvalidQuery("foreign key has no columns")
+	}
+
+	if foreignKey.ReferencedTable == "" {
+		return errInvalidQuery("foreign key has no referenced table")
+	}
+
+	if len(foreignKey.ReferencedColumns) != len(foreignKey.Columns) {
+		return errInvalidQuery("foreign key must reference as many columns as it has")
+	}
+
+	for _, action := range []string{foreignKey.OnDelete, foreignKey.OnUpdate} {
+		if action == "" {
+			continue
+		}
+
+		switch strings.ToUpper(action) {
+		case FOREIGN_KEY_ACTION_CASCADE, FOREIGN_KEY_ACTION_NO_ACTION, FOREIGN_KEY_ACTION_SET_NULL:
+		case FOREIGN_KEY_ACTION_RESTRICT:
+			// MSSQL uses NO ACTION, which checks immediately as well
+			if b.syntax() == DIALECT_MSSQL {
+				return b.errUnsupportedFeature("foreign key action " + FOREIGN_KEY_ACTION_RESTRICT)
+			}
+		case FOREIGN_KEY_ACTION_SET_DEFAULT:
+			// Parsed, but rejected by the MySQL storage engines
+			if b.syntax() == DIALECT_MYSQL {
+				return b.errUnsupportedFeature("foreign key action " + FOREIGN_KEY_ACTION_SET_DEFAULT)
+			}
+		default:
+			return errInvalidQuery("foreign key action " + action + " is not supported")
+		}
+	}
+
+	return nil
+}
+
+// foreignKeys returns the foreign keys of the columns (References),
+// followed by the foreign keys of the table
+func (b *Builder) foreignKeys() []ForeignKey {
+	foreignKeys := []ForeignKey{}
+
+	for _, column := range b.sqlColumns {
+		if column.References != nil {
+			foreignKeys = append(foreignKeys, columnForeignKey(column))
+		}
+	}
+
+	return append(foreignKeys, b.sqlForeignKeys...)
+}
+
+// columnForeignKey returns the foreign key of a column, with the column as its column
+func columnForeignKey(column Column) ForeignKey {
+	foreignKey := *column.References
+	foreignKey.Columns = []string{column.Name}
+
+	return foreignKey
+}
+
+// tableDefinitionToSql converts the columns and the foreign keys
+// of the table to SQL, as in the CREATE TABLE statement
+func (b *Builder) tableDefinitionToSql() string {
+	sql := b.columnsToSQL(b.sqlColumns)
+
+	for _, foreignKey := range b.foreignKeys() {
+		if err := b.validateForeignKey(foreignKey); err != nil {
+			b.fail(err)
+		}
+
+		sql += ", " + b.foreignKeyToSql(foreignKey)
+	}
+
+	return sql
+}
+
+// foreignKeyToSql converts a foreign key to a table constraint, i.e.
+// CONSTRAINT "fk_orders_users" FOREIGN KEY ("user_id") REFERENCES "users" ("id")
+func (b *Builder) foreignKeyToSql(foreignKey ForeignKey) string {
+	columns := lo.Map(foreignKey.Columns, func(column string, _ int) string {
+		return b.quoteColumn(column)
+	})
+
+	return b.foreignKeyConstraintToSql(foreignKey) + "FOREIGN KEY (" + strings.Join(columns, ", ") + ") " + b.foreignKeyReferencesToSql(foreignKey)
+}
+
+// foreignKeyColumnToSql converts a foreign key to a column constraint,
+// i.e. CONSTRAINT "fk_orders_users" REFERENCES "users" ("id")
+func (b *Builder) foreignKeyColumnToSql(foreignKey ForeignKey) string {
+	return b.foreignKeyConstraintToSql(foreignKey) + b.foreignKeyReferencesToSql(foreignKey)
+}
+
+func (b *Builder) foreignKeyConstraintToSql(foreignKey ForeignKey) string {
+	if foreignKey.Name == "" {
+		return ""
+	}
+
+	return "CONSTRAINT " + b.quote(foreignKey.Name, "column") + " "
+}
+
+func (b *Builder) foreignKeyReferencesToSql(foreignKey ForeignKey) string {
+	referencedColumns := lo.Map(foreignKey.ReferencedColumns, func(column string, _ int) string {
+		return b.quoteColumn(column)
+	})
+
+	sql := "REFERENCES " + b.quoteTable(foreignKey.ReferencedTable) + " (" + strings.Join(referencedColumns, ", ") + ")"
+
+	if foreignKey.OnDelete != "" {
+		sql += " ON DELETE " + strings.ToUpper(foreignKey.OnDelete)
+	}
+
+	if foreignKey.OnUpdate != "" {
+		sql += " ON UPDATE " + strings.ToUpper(foreignKey.OnUpdate)
+	}
+
+	return sql
+}
+
+// columnReferencesToSql converts the foreign key of a column added to a
+// table to SQL, following the column definition. SQLite only supports it
+// as a column constraint, MySQL ignores the column constraints, so there
+// it is a table constraint added with the column
+func (b *Builder) columnReferencesToSql(column Column) string {
+	if column.References == nil {
+		return ""
+	}
+
+	foreignKey := columnForeignKey(column)
+	if err := b.validateForeignKey(foreignKey); err != nil {
+		b.fail(err)
+	}
+
+	switch b.syntax() {
+	case DIALECT_SQLITE:
+		return " " + b.foreignKeyColumnToSql(foreignKey)
+	case DIALECT_MSSQL:
+		return ", " + b.foreignKeyToSql(foreignKey)
+	default:
+		return ", ADD " + b.foreignKeyToSql(foreignKey)
+	}
+}
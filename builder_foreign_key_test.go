@@ -0,0 +1,189 @@
+package sb
+
+import (
+	"errors"
+	"testing"
+)
+
+func foreignKeyTestBuilder(dialect string) BuilderInterface {
+	return NewBuilder(dialect).
+		Table("orders").
+		Column(Column{Name: "id", Type: COLUMN_TYPE_INTEGER, PrimaryKey: true}).
+		Column(Column{
+			Name: "user_id",
+			Type: COLUMN_TYPE_INTEGER,
+			References: &ForeignKey{
+				ReferencedTable:   "users",
+				ReferencedColumns: []string{"id"},
+				OnDelete:          FOREIGN_KEY_ACTION_CASCADE,
+			},
+		}).
+		Column(Column{Name: "product_id", Type: COLUMN_TYPE_INTEGER, Nullable: true}).
+		ForeignKey(ForeignKey{
+			Name:              "fk_orders_products",
+			Columns:           []string{"product_id"},
+			ReferencedTable:   "products",
+			ReferencedColumns: []string{"id"},
+			OnDelete:          FOREIGN_KEY_ACTION_SET_NULL,
+			OnUpdate:          FOREIGN_KEY_ACTION_CASCADE,
+		})
+}
+
+func TestBuilderForeignKeyCreate(t *testing.T) {
+	tests := []struct {
+		dialect  string
+		expected string
+	}{
+		{DIALECT_MYSQL, "CREATE TABLE `orders`(`id` BIGINT(20) PRIMARY KEY NOT NULL, `user_id` BIGINT(20) NOT NULL, `product_id` BIGINT(20), FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE, CONSTRAINT `fk_orders_products` FOREIGN KEY (`product_id`) REFERENCES `products` (`id`) ON DELETE SET NULL ON UPDATE CASCADE);"},
+		{DIALECT_POSTGRES, `CREATE TABLE "orders"("id" INTEGER PRIMARY KEY NOT NULL, "user_id" INTEGER NOT NULL, "product_id" INTEGER, FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE, CONSTRAINT "fk_orders_products" FOREIGN KEY ("product_id") REFERENCES "products" ("id") ON DELETE SET NULL ON UPDATE CASCADE);`},
+		{DIALECT_SQLITE, `CREATE TABLE "orders"("id" INTEGER PRIMARY KEY NOT NULL, "user_id" INTEGER NOT NULL, "product_id" INTEGER, FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE, CONSTRAINT "fk_orders_products" FOREIGN KEY ("product_id") REFERENCES "products" ("id") ON DELETE SET NULL ON UPDATE CASCADE);`},
+		{DIALECT_MSSQL, `CREATE TABLE [orders] ([id] INTEGER PRIMARY KEY NOT NULL, [user_id] INTEGER NOT NULL, [product_id] INTEGER, FOREIGN KEY ([user_id]) REFERENCES [users] ([id]) ON DELETE CASCADE, CONSTRAINT [fk_orders_products] FOREIGN KEY ([product_id]) REFERENCES [products] ([id]) ON DELETE SET NULL ON UPDATE CASCADE);`},
+	}
+
+	for _, test := range tests {
+		sql := foreignKeyTestBuilder(test.dialect).Create()
+		if sql != test.expected {
+			t.Fatal("Expected:\n", test.expected, "\nbut found:\n", sql)
+		}
+	}
+}
+
+func TestBuilderTableForeignKeyAdd(t *testing.T) {
+	foreignKey := ForeignKey{
+		Name:              "fk_orders_users",
+		Columns:           []string{"user_id"},
+		ReferencedTable:   "users",
+		ReferencedColumns: []string{"id"},
+		OnDelete:          "cascade",
+	}
+
+	tests := []struct {
+		dialect  string
+		expected string
+	}{
+		{DIALECT_MYSQL, "ALTER TABLE `orders` ADD CONSTRAINT `fk_orders_users` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE;"},
+		{DIALECT_POSTGRES, `ALTER TABLE "orders" ADD CONSTRAINT "fk_orders_users" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;`},
+		{DIALECT_MSSQL, `ALTER TABLE [orders] ADD CONSTRAINT [fk_orders_users] FOREIGN KEY ([user_id]) REFERENCES [users] ([id]) ON DELETE CASCADE;`},
+	}
+
+	for _, test := range tests {
+		sql, err := NewBuilder(test.dialect).TableForeignKeyAdd("orders", foreignKey)
+		if err != nil {
+			t.Fatal("Error must be NIL but got: ", err.Error())
+		}
+
+		if sql != test.expected {
+			t.Fatal("Expected:\n", test.expected, "\nbut found:\n", sql)
+		}
+	}
+}
+
+func TestBuilderTableForeignKeyAddSqlite(t *testing.T) {
+	_, err := NewBuilder(DIALECT_SQLITE).
+		Column(Column{Name: "id", Type: COLUMN_TYPE_INTEGER, PrimaryKey: true}).
+		Column(Column{Name: "user_id", Type: COLUMN_TYPE_INTEGER}).
+		TableForeignKeyAdd("orders", ForeignKey{
+			Name:              "fk_orders_users",
+			Columns:           []string{"user_id"},
+			ReferencedTable:   "users",
+			ReferencedColumns: []string{"id"},
+		})
+
+	if !errors.Is(err, ErrUnsupportedFeature) {
+		t.Fatal("Expected ErrUnsupportedFeature, as the table must be rebuilt, but found: ", err)
+	}
+
+	_, err = foreignKeyTestBuilder(DIALECT_SQLITE).TableForeignKeyDrop("orders", "fk_orders_products")
+
+	if !errors.Is(err, ErrUnsupportedFeature) {
+		t.Fatal("Expected ErrUnsupportedFeature, as the table must be rebuilt, but found: ", err)
+	}
+}
+
+func TestBuilderTableForeignKeyDrop(t *testing.T) {
+	tests := []struct {
+		dialect  string
+		expected string
+	}{
+		{DIALECT_MYSQL, "ALTER TABLE `orders` DROP FOREIGN KEY `fk_orders_products`;"},
+		{DIALECT_POSTGRES, `ALTER TABLE "orders" DROP CONSTRAINT "fk_orders_products";`},
+		{DIALECT_MSSQL, `ALTER TABLE [orders] DROP CONSTRAINT [fk_orders_products];`},
+	}
+
+	for _, test := range tests {
+		sql, err := foreignKeyTestBuilder(test.dialect).TableForeignKeyDrop("orders", "fk_orders_products")
+		if err != nil {
+			t.Fatal("Error must be NIL but got: ", err.Error())
+		}
+
+		if sql != test.expected {
+			t.Fatal("Expected:\n", test.expected, "\nbut found:\n", sql)
+		}
+	}
+
+	_, err := NewBuilder(DIALECT_POSTGRES).TableForeignKeyDrop("orders", "")
+	if !errors.Is(err, ErrInvalidQuery) {
+		t.Fatal("Expected ErrInvalidQuery without a name but found: ", err)
+	}
+}
+
+func TestBuilderTableColumnAddReferences(t *testing.T) {
+	column := Column{
+		Name:       "user_id",
+		Type:       COLUMN_TYPE_INTEGER,
+		Nullable:   true,
+		References: &ForeignKey{Name: "fk_orders_users", ReferencedTable: "users", ReferencedColumns: []string{"id"}},
+	}
+
+	tests := []struct {
+		dialect  string
+		expected string
+	}{
+		{DIALECT_MYSQL, "ALTER TABLE `orders` ADD `user_id` BIGINT(20), ADD CONSTRAINT `fk_orders_users` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`);"},
+		{DIALECT_POSTGRES, `ALTER TABLE "orders" ADD "user_id" INTEGER, ADD CONSTRAINT "fk_orders_users" FOREIGN KEY ("user_id") REFERENCES "users" ("id");`},
+		{DIALECT_SQLITE, `ALTER TABLE "orders" ADD COLUMN "user_id" INTEGER CONSTRAINT "fk_orders_users" REFERENCES "users" ("id");`},
+		{DIALECT_MSSQL, `ALTER TABLE [orders] ADD [user_id] INTEGER, CONSTRAINT [fk_orders_users] FOREIGN KEY ([user_id]) REFERENCES [users] ([id]);`},
+	}
+
+	for _, test := range tests {
+		sql, err := NewBuilder(test.dialect).TableColumnAdd("orders", column)
+		if err != nil {
+			t.Fatal("Error must be NIL but got: ", err.Error())
+		}
+
+		if sql != test.expected {
+			t.Fatal("Expected:\n", test.expected, "\nbut found:\n", sql)
+		}
+	}
+}
+
+func TestBuilderForeignKeyInvalid(t *testing.T) {
+	tests := []struct {
+		dialect    string
+		foreignKey ForeignKey
+		expected   error
+	}{
+		{DIALECT_SQLITE, ForeignKey{ReferencedTable: "users", ReferencedColumns: []string{"id"}}, ErrInvalidQuery},
+		{DIALECT_SQLITE, ForeignKey{Columns: []string{"user_id"}, ReferencedColumns: []string{"id"}}, ErrInvalidQuery},
+		{DIALECT_SQLITE, ForeignKey{Columns: []string{"user_id"}, ReferencedTable: "users", ReferencedColumns: []string{"id", "tenant_id"}}, ErrInvalidQuery},
+		{DIALECT_SQLITE, ForeignKey{Columns: []string{"user_id"}, ReferencedTable: "users", ReferencedColumns: []string{"id"}, OnDelete: "DROP"}, ErrInvalidQuery},
+		{DIALECT_MSSQL, ForeignKey{Columns: []string{"user_id"}, ReferencedTable: "users", ReferencedColumns: []string{"id"}, OnDelete: FOREIGN_KEY_ACTION_RESTRICT}, ErrUnsupportedFeature},
+		{DIALECT_MYSQL, ForeignKey{Columns: []string{"user_id"}, ReferencedTable: "users", ReferencedColumns: []string{"id"}, OnUpdate: FOREIGN_KEY_ACTION_SET_DEFAULT}, ErrUnsupportedFeature},
+	}
+
+	for _, test := range tests {
+		b := NewBuilder(test.dialect).Table("orders").ForeignKey(test.foreignKey)
+		if !errors.Is(b.Err(), test.expected) {
+			t.Fatal("Expected ", test.expected, " but found: ", b.Err())
+		}
+	}
+
+	b := NewBuilder(DIALECT_SQLITE).Table("orders").Column(Column{
+		Name:       "user_id",
+		Type:       COLUMN_TYPE_INTEGER,
+		References: &ForeignKey{ReferencedColumns: []string{"id"}},
+	})
+	if !errors.Is(b.Err(), ErrInvalidQuery) {
+		t.Fatal("Expected ErrInvalidQuery but found: ", b.Err())
+	}
+}
@@ -0,0 +1,30 @@
+package sb
+
+// Having adds a condition to the HAVING clause, filtering the groups
+// of a grouped select. It has the same semantics as Where, and
+// compares either a column, or an aggregate set as the Expression
+//
+//	Example:
+//	// SELECT "country", COUNT(*) FROM "users" GROUP BY "country" HAVING COUNT(*) > 5;
+//	NewBuilder(DIALECT_SQLITE).
+//		Table("users").
+//		GroupBy(GroupBy{Column: "country"}).
+//		Having(Where{Expression: &Expression{Function: FUNCTION_COUNT}, Operator: ">", Values: []any{5}}).
+//		SelectExpression(Expression{Function: FUNCTION_COUNT}).
+//		Select([]string{"country"})
+func (b *Builder) Having(having Where) BuilderInterface {
+	b = b.mutable()
+	b.sqlHaving = append(b.sqlHaving, having)
+	return b
+}
+
+// havingToSql converts the having conditions to SQL
+func (b *Builder) havingToSql(havings []Where) string {
+	sql := b.whereToSqlConditions(havings)
+
+	if sql != "" {
+		return " HAVING " + sql
+	}
+
+	return ""
+}
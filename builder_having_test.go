@@ -0,0 +1,109 @@
+package sb
+
+import (
+	"testing"
+)
+
+func TestBuilderHaving(t *testing.T) {
+	tests := []struct {
+		dialect  string
+		expected string
+	}{
+		{DIALECT_MYSQL, "SELECT `country`, COUNT(*) FROM `users` WHERE `status` = 'active' GROUP BY `country` HAVING COUNT(*) > 5 ORDER BY `country` ASC;"},
+		{DIALECT_POSTGRES, `SELECT "country", COUNT(*) FROM "users" WHERE "status" = 'active' GROUP BY "country" HAVING COUNT(*) > 5 ORDER BY "country" ASC;`},
+		{DIALECT_SQLITE, `SELECT "country", COUNT(*) FROM "users" WHERE "status" = 'active' GROUP BY "country" HAVING COUNT(*) > 5 ORDER BY "country" ASC;`},
+	}
+
+	for _, test := range tests {
+		sql := NewBuilder(test.dialect).
+			Table("users").
+			Where(Where{Column: "status", Operator: "=", Value: "active"}).
+			GroupBy(GroupBy{Column: "country"}).
+			Having(Where{Expression: &Expression{Function: FUNCTION_COUNT}, Operator: ">", Values: []any{5}}).
+			OrderBy("country", ASC).
+			SelectExpression(Expression{Function: FUNCTION_COUNT}).
+			Select([]string{"country"})
+
+		if sql != test.expected {
+			t.Fatal("Expected:\n", test.expected, "\nbut found:\n", sql)
+		}
+	}
+}
+
+func TestBuilderHavingNested(t *testing.T) {
+	sql := NewBuilder(DIALECT_SQLITE).
+		Table("orders").
+		GroupBy(GroupBy{Column: "user_id"}).
+		Having(Where{Expression: &Expression{Function: FUNCTION_SUM, Columns: []string{"total"}}, Operator: ">=", Values: []any{100}}).
+		Having(Where{Type: "OR", Children: []Where{
+			{Expression: &Expression{Function: FUNCTION_COUNT}, Operator: ">", Values: []any{10}},
+			{Expression: &Expression{Function: FUNCTION_MAX, Columns: []string{"total"}, Alias: "max_total"}, Operator: OPERATOR_BETWEEN, Values: []any{50, 60}},
+		}}).
+		Select([]string{"user_id"})
+
+	expected := `SELECT "user_id" FROM "orders" GROUP BY "user_id" HAVING SUM("total") >= 100 OR (COUNT(*) > 10 AND MAX("total") BETWEEN 50 AND 60);`
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+}
+
+func TestBuilderHavingWithParams(t *testing.T) {
+	sql, params, err := NewBuilder(DIALECT_POSTGRES).
+		Table("orders").
+		Where(Where{Column: "status", Operator: "=", Value: "paid"}).
+		GroupBy(GroupBy{Column: "user_id"}).
+		Having(Where{Expression: &Expression{Function: FUNCTION_COUNT}, Operator: ">", Values: []any{5}}).
+		SelectWithParams([]string{"user_id"})
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	expected := `SELECT "user_id" FROM "orders" WHERE "status" = $1 GROUP BY "user_id" HAVING COUNT(*) > $2;`
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+
+	if len(params) != 2 || params[0] != "paid" || params[1] != 5 {
+		t.Fatal("Unexpected params: ", params)
+	}
+}
+
+func TestBuilderHavingSqliteExec(t *testing.T) {
+	db, err := initSqliteDB("test_builder_having.db")
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	defer db.Close()
+
+	statements := []string{
+		NewBuilder(DIALECT_SQLITE).Table("orders").
+			Column(Column{Name: "user_id", Type: COLUMN_TYPE_STRING}).
+			Column(Column{Name: "total", Type: COLUMN_TYPE_INTEGER}).
+			Create(),
+		`INSERT INTO "orders" ("user_id", "total") VALUES ('a', 10), ('a', 20), ('b', 5)`,
+	}
+
+	for _, statement := range statements {
+		_, err = db.Exec(statement)
+		if err != nil {
+			t.Fatal("Error must be NIL but got: ", err.Error(), " for ", statement)
+		}
+	}
+
+	rows, err := db.SelectToMapString(NewBuilder(DIALECT_SQLITE).
+		Table("orders").
+		GroupBy(GroupBy{Column: "user_id"}).
+		Having(Where{Expression: &Expression{Function: FUNCTION_COUNT}, Operator: ">", Values: []any{1}}).
+		Select([]string{"user_id"}))
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	if len(rows) != 1 || rows[0]["user_id"] != "a" {
+		t.Fatal("Expected only user a but got: ", rows)
+	}
+}
@@ -0,0 +1,168 @@
+package sb
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// Limits of a single multi-row INSERT statement. The parameter limits are
+// applied with inlined values as well, so both modes produce the same chunks
+const (
+	// SQLite before 3.32 allows 999 host parameters (32766 after), and
+	// before 3.8.8 at most 500 rows in a VALUES clause
+	insertManySqliteMaxParams = 999
+	insertManySqliteMaxRows   = 500
+
+	// MSSQL allows 1000 rows in a VALUES clause, and 2100 parameters,
+	// of which the drivers reserve two for sp_executesql
+	insertManyMssqlMaxParams = 2098
+	insertManyMssqlMaxRows   = 1000
+
+	// MySQL and PostgreSQL allow 65535 parameters in a prepared statement
+	insertManyDefaultMaxParams = 65535
+)
+
+// InsertMany inserts multiple rows into the table, using multi-row
+// VALUES statements. The rows are split into as many statements as needed
+// to stay within the limits of the dialect. All the rows must have
+// the same columns
+//
+//	Example:
+//	sqls, err := NewBuilder(DIALECT_MYSQL).
+//		Table("users").
+//		InsertMany([]map[string]string{
+//			{"first_name": "Tom", "last_name": "Jones"},
+//			{"first_name": "Ann", "last_name": "Smith"},
+//		})
+//	// sqls: []string{"INSERT INTO `users` (`first_name`, `last_name`) VALUES ('Tom', 'Jones'), ('Ann', 'Smith');"}
+func (b *Builder) InsertMany(rows []map[string]string) (sqls []string, err error) {
+	defer recoverError(&err)
+
+	columns, err := b.insertManyColumns(rows)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sqls = []string{}
+
+	for _, chunk := range lo.Chunk(rows, b.insertManyChunkSize(len(columns))) {
+		sqls = append(sqls, b.insertManyToSql(columns, chunk))
+	}
+
+	return sqls, nil
+}
+
+// InsertManyWithParams works like InsertMany, but returns the values as
+// bound parameters, one list of parameters for each statement
+func (b *Builder) InsertManyWithParams(rows []map[string]string) (sqls []string, params [][]any, err error) {
+	defer recoverError(&err)
+
+	columns, err := b.insertManyColumns(rows)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sqls = []string{}
+	params = [][]any{}
+
+	for _, chunk := range lo.Chunk(rows, b.insertManyChunkSize(len(columns))) {
+		sql, chunkParams := b.withParams(func(b *Builder) string {
+			return b.insertManyToSql(columns, chunk)
+		})
+
+		sqls = append(sqls, sql)
+		params = append(params, chunkParams)
+	}
+
+	return sqls, params, nil
+}
+
+// insertManyColumns returns the sorted column names of the rows,
+// and checks that all the rows have the same columns
+func (b *Builder) insertManyColumns(rows []map[string]string) ([]string, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	if b.sqlTableName == "" {
+		return nil, errNoTable("InsertMany")
+	}
+
+	if len(rows) == 0 {
+		return nil, errInvalidQuery("in method InsertMany() no rows specified to insert")
+	}
+
+	columns := lo.Keys(rows[0])
+	sort.Strings(columns)
+
+	if len(columns) == 0 {
+		return nil, errInvalidQuery("in method InsertMany() the rows have no columns")
+	}
+
+	for _, row := range rows[1:] {
+		if len(row) != len(columns) {
+			return nil, errInvalidQuery("in method InsertMany() all the rows must have the same columns")
+		}
+
+		for _, column := range columns {
+			if _, ok := row[column]; !ok {
+				return nil, errInvalidQuery("in method InsertMany() all the rows must have the same columns, column " + column + " is missing")
+			}
+		}
+	}
+
+	return columns, nil
+}
+
+// insertManyChunkSize returns the number of rows allowed in a single
+// statement for the number of columns. It fails when a single row
+// has more values than the parameters allowed in a statement
+func (b *Builder) insertManyChunkSize(columnCount int) int {
+	maxParams := insertManyDefaultMaxParams
+	maxRows := 0
+
+	switch b.syntax() {
+	case DIALECT_SQLITE:
+		maxParams = insertManySqliteMaxParams
+		maxRows = insertManySqliteMaxRows
+	case DIALECT_MSSQL:
+		maxParams = insertManyMssqlMaxParams
+		maxRows = insertManyMssqlMaxRows
+	}
+
+	if columnCount > maxParams {
+		b.fail(errInvalidQuery("in method InsertMany() " + strconv.Itoa(columnCount) + " columns exceed the " + strconv.Itoa(maxParams) + " parameters of a statement for dialect " + b.Dialect))
+	}
+
+	size := maxParams / columnCount
+
+	if maxRows > 0 {
+		size = min(size, maxRows)
+	}
+
+	return size
+}
+
+// insertManyToSql converts a chunk of rows to a multi-row INSERT statement
+func (b *Builder) insertManyToSql(columns []string, rows []map[string]string) string {
+	columnNames := lo.Map(columns, func(column string, _ int) string {
+		return b.quoteColumn(column)
+	})
+
+	values := []string{}
+
+	for _, row := range rows {
+		rowValues := []string{}
+		for _, column := range columns {
+			rowValues = append(rowValues, b.quoteValue(row[column]))
+		}
+		values = append(values, "("+strings.Join(rowValues, ", ")+")")
+	}
+
+	return "INSERT INTO " + b.quoteTable(b.sqlTableName) + " (" + strings.Join(columnNames, ", ") + ")" + b.outputToSql("INSERTED") + " VALUES " + strings.Join(values, ", ") + b.returningToSql() + ";"
+}
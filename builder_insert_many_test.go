@@ -0,0 +1,207 @@
+package sb
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestBuilderInsertMany(t *testing.T) {
+	tests := []struct {
+		dialect  string
+		expected string
+	}{
+		{DIALECT_MYSQL, "INSERT INTO `users` (`first_name`, `last_name`) VALUES ('Tom', 'Jones'), ('Ann', 'Smith');"},
+		{DIALECT_POSTGRES, `INSERT INTO "users" ("first_name", "last_name") VALUES ('Tom', 'Jones'), ('Ann', 'Smith');`},
+		{DIALECT_SQLITE, `INSERT INTO "users" ("first_name", "last_name") VALUES ('Tom', 'Jones'), ('Ann', 'Smith');`},
+	}
+
+	for _, test := range tests {
+		sqls, err := NewBuilder(test.dialect).
+			Table("users").
+			InsertMany([]map[string]string{
+				{"first_name": "Tom", "last_name": "Jones"},
+				{"last_name": "Smith", "first_name": "Ann"},
+			})
+
+		if err != nil {
+			t.Fatal("Error must be NIL but got: ", err.Error())
+		}
+
+		if len(sqls) != 1 {
+			t.Fatal("Expected 1 statement but got: ", len(sqls))
+		}
+
+		if sqls[0] != test.expected {
+			t.Fatal("Expected:\n", test.expected, "\nbut found:\n", sqls[0])
+		}
+	}
+}
+
+func TestBuilderInsertManyDifferentColumns(t *testing.T) {
+	tests := [][]map[string]string{
+		{{"a": "1"}, {"b": "2"}},
+		{{"a": "1"}, {"a": "2", "b": "3"}},
+		{{"a": "1", "b": "2"}, {"a": "3"}},
+		{},
+		{{}},
+	}
+
+	for _, rows := range tests {
+		_, err := NewBuilder(DIALECT_SQLITE).Table("users").InsertMany(rows)
+
+		if err == nil {
+			t.Fatal("Error must NOT be NIL for rows: ", rows)
+		}
+	}
+
+	_, err := NewBuilder(DIALECT_SQLITE).InsertMany([]map[string]string{{"a": "1"}})
+
+	if err == nil {
+		t.Fatal("Error must NOT be NIL when no table is specified")
+	}
+}
+
+func TestBuilderInsertManyChunks(t *testing.T) {
+	tests := []struct {
+		dialect  string
+		columns  int
+		rows     int
+		expected []int
+	}{
+		{DIALECT_SQLITE, 3, 700, []int{333, 333, 34}},
+		{DIALECT_SQLITE, 1, 1200, []int{500, 500, 200}},
+		{DIALECT_MSSQL, 1, 1500, []int{1000, 500}},
+		{DIALECT_MSSQL, 3, 1500, []int{699, 699, 102}},
+		{DIALECT_MSSQL, 7, 600, []int{299, 299, 2}},
+		{DIALECT_POSTGRES, 2, 1500, []int{1500}},
+		{DIALECT_MYSQL, 10, 7000, []int{6553, 447}},
+	}
+
+	for _, test := range tests {
+		rows := []map[string]string{}
+		for i := 0; i < test.rows; i++ {
+			row := map[string]string{}
+			for c := 0; c < test.columns; c++ {
+				row["c"+strconv.Itoa(c)] = strconv.Itoa(i)
+			}
+			rows = append(rows, row)
+		}
+
+		sqls, params, err := NewBuilder(test.dialect).Table("items").InsertManyWithParams(rows)
+
+		if err != nil {
+			t.Fatal("Error must be NIL but got: ", err.Error())
+		}
+
+		if len(sqls) != len(test.expected) || len(params) != len(test.expected) {
+			t.Fatal("Expected ", len(test.expected), " statements for ", test.dialect, " but got: ", len(sqls))
+		}
+
+		for i, expectedRows := range test.expected {
+			if len(params[i]) != expectedRows*test.columns {
+				t.Fatal("Expected ", expectedRows*test.columns, " params in statement ", i, " for ", test.dialect, " but got: ", len(params[i]))
+			}
+
+			if strings.Count(sqls[i], "(") != expectedRows+1 {
+				t.Fatal("Expected ", expectedRows, " rows in statement ", i, " for ", test.dialect)
+			}
+		}
+	}
+}
+
+func TestBuilderInsertManyTooManyColumns(t *testing.T) {
+	row := map[string]string{}
+	for i := 0; i < 1200; i++ {
+		row["c"+strconv.Itoa(i)] = strconv.Itoa(i)
+	}
+
+	_, err := NewBuilder(DIALECT_SQLITE).Table("users").InsertMany([]map[string]string{row})
+
+	if !errors.Is(err, ErrInvalidQuery) {
+		t.Fatal("Expected ErrInvalidQuery for a row over the parameter limit but found: ", err)
+	}
+
+	_, _, err = NewBuilder(DIALECT_SQLITE).Table("users").InsertManyWithParams([]map[string]string{row})
+
+	if !errors.Is(err, ErrInvalidQuery) {
+		t.Fatal("Expected ErrInvalidQuery for a row over the parameter limit but found: ", err)
+	}
+
+	_, err = NewBuilder(DIALECT_MYSQL).Table("users").InsertMany([]map[string]string{row})
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+}
+
+func TestBuilderInsertManyWithParams(t *testing.T) {
+	sqls, params, err := NewBuilder(DIALECT_POSTGRES).
+		Table("users").
+		InsertManyWithParams([]map[string]string{
+			{"first_name": "Tom", "last_name": "Jones"},
+			{"first_name": "Ann", "last_name": "Smith"},
+		})
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	expected := `INSERT INTO "users" ("first_name", "last_name") VALUES ($1, $2), ($3, $4);`
+	if len(sqls) != 1 || sqls[0] != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sqls)
+	}
+
+	if len(params) != 1 || len(params[0]) != 4 || params[0][0] != "Tom" || params[0][3] != "Smith" {
+		t.Fatal("Unexpected params: ", params)
+	}
+}
+
+func TestBuilderInsertManySqliteExec(t *testing.T) {
+	db, err := initSqliteDB("test_builder_insert_many.db")
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	defer db.Close()
+
+	_, err = db.Exec(NewBuilder(DIALECT_SQLITE).Table("items").
+		Column(Column{Name: "id", Type: COLUMN_TYPE_STRING, PrimaryKey: true}).
+		Column(Column{Name: "name", Type: COLUMN_TYPE_STRING}).
+		Column(Column{Name: "price", Type: COLUMN_TYPE_STRING}).
+		Create())
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	rows := []map[string]string{}
+	for i := 0; i < 1000; i++ {
+		rows = append(rows, map[string]string{"id": strconv.Itoa(i), "name": "item " + strconv.Itoa(i), "price": "1.5"})
+	}
+
+	sqls, params, err := NewBuilder(DIALECT_SQLITE).Table("items").InsertManyWithParams(rows)
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	for i, sql := range sqls {
+		_, err = db.Exec(sql, params[i]...)
+		if err != nil {
+			t.Fatal("Error must be NIL but got: ", err.Error())
+		}
+	}
+
+	count, err := db.SelectToMapString(`SELECT COUNT(*) AS "count" FROM "items"`)
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	if count[0]["count"] != "1000" {
+		t.Fatal("Expected 1000 rows but got: ", count[0]["count"])
+	}
+}
@@ -0,0 +1,252 @@
+package sb
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// Join represents a table joined to the main table of the query
+//
+//	Example:
+//	NewBuilder(DIALECT_MYSQL).
+//		Table("users").
+//		LeftJoin(Join{
+//			Table: "orders",
+//			Alias: "o",
+//			On:    []JoinOn{{Column: "users.id", OtherColumn: "o.user_id"}},
+//		}).
+//		Select([]string{"users.id", "o.total"})
+type Join struct {
+	// Type is the join type, one of the JOIN_TYPE_* constants,
+	// set automatically by the Join, LeftJoin, etc. methods
+	Type string
+
+	// Table is the name of the joined table
+	Table string
+
+	// Alias is an optional alias for the joined table
+	Alias string
+
+	// On are the column to column conditions, combined with AND
+	On []JoinOn
+
+	// Where are additional conditions, with the same semantics as Builder.Where
+	Where []Where
+
+	// Using are the columns, having the same name in both tables, to join on
+	Using []string
+}
+
+// JoinOn represents a column to column join condition
+type JoinOn struct {
+	Column      string
+	Operator    string
+	OtherColumn string
+}
+
+// Join adds an inner join to the query
+func (b *Builder) Join(join Join) BuilderInterface {
+	return b.joinAdd(JOIN_TYPE_INNER, join)
+}
+
+// LeftJoin adds a left outer join to the query
+func (b *Builder) LeftJoin(join Join) BuilderInterface {
+	return b.joinAdd(JOIN_TYPE_LEFT, join)
+}
+
+// RightJoin adds a right outer join to the query (SQLite 3.39 and later)
+func (b *Builder) RightJoin(join Join) BuilderInterface {
+	return b.joinAdd(JOIN_TYPE_RIGHT, join)
+}
+
+// FullJoin adds a full outer join to the query (not supported by MySQL)
+func (b *Builder) FullJoin(join Join) BuilderInterface {
+	return b.joinAdd(JOIN_TYPE_FULL, join)
+}
+
+// CrossJoin adds a cross join to the query, the conditions are ignored
+func (b *Builder) CrossJoin(join Join) BuilderInterface {
+	return b.joinAdd(JOIN_TYPE_CROSS, join)
+}
+
+func (b *Builder) joinAdd(joinType string, join Join) BuilderInterface {
+	if join.Table == "" {
+		return b.errorAdd(errInvalidQuery("join table is required"))
+	}
+
+	join.Type = joinType
+	b = b.mutable()
+	b.sqlJoin = append(b.sqlJoin, join)
+
+	return b
+}
+
+// joinToSql converts the joins to SQL
+func (b *Builder) joinToSql(joins []Join) string {
+	sql := ""
+
+	for _, join := range joins {
+		sql += " " + b.joinToSqlSingle(join)
+	}
+
+	return sql
+}
+
+func (b *Builder) joinToSqlSingle(join Join) string {
+	joinType := lo.Ternary(join.Type == "", JOIN_TYPE_INNER, join.Type)
+
+	if joinType == JOIN_TYPE_RIGHT && !b.supports(FEATURE_RIGHT_JOIN) {
+		b.fail(b.errUnsupportedFeature("right join"))
+	}
+
+	if joinType == JOIN_TYPE_FULL && !b.supports(FEATURE_FULL_JOIN) {
+		b.fail(b.errUnsupportedFeature("full join"))
+	}
+
+	sql := joinType + " JOIN " + b.joinTableToSql(join)
+
+	if joinType == JOIN_TYPE_CROSS {
+		return sql
+	}
+
+	// MSSQL has no USING, the columns are converted to ON conditions
+	if len(join.Using) > 0 && b.syntax() != DIALECT_MSSQL {
+		using := lo.Map(join.Using, func(column string, _ int) string {
+			return b.quoteColumn(column)
+		})
+		return sql + " USING (" + strings.Join(using, ", ") + ")"
+	}
+
+	conditions := b.joinConditionsToSql(join)
+
+	if conditions != "" {
+		sql += " ON " + conditions
+	}
+
+	return sql
+}
+
+// joinTableToSql converts the joined table with its alias to SQL
+func (b *Builder) joinTableToSql(join Join) string {
+	if join.Alias == "" {
+		return b.quoteTable(join.Table)
+	}
+
+	return b.quoteTable(join.Table) + " AS " + b.quoteTable(join.Alias)
+}
+
+// joinConditionsToSql converts the conditions of the join to SQL,
+// without the ON keyword. The USING columns are converted as well,
+// so the result can be used where USING is not allowed
+func (b *Builder) joinConditionsToSql(join Join) string {
+	joinTable := lo.Ternary(join.Alias != "", join.Alias, join.Table)
+
+	conditions := []string{}
+
+	for _, column := range join.Using {
+		conditions = append(conditions, b.quoteColumn(b.sqlTableName+"."+column)+" = "+b.quoteColumn(joinTable+"."+column))
+	}
+
+	for _, on := range join.On {
+		conditions = append(conditions, b.quoteColumn(on.Column)+" "+b.operatorToSql(on.Operator)+" "+b.quoteColumn(on.OtherColumn))
+	}
+
+	if len(join.Where) > 0 {
+		where := b.whereToSqlConditions(join.Where)
+		if len(conditions) > 0 && where != "" {
+			where = "(" + where + ")"
+		}
+		if where != "" {
+			conditions = append(conditions, where)
+		}
+	}
+
+	return strings.Join(conditions, " AND ")
+}
+
+// joinToSqlAsFrom converts the joins to the FROM (or USING) list used by
+// the PostgreSQL and SQLite UPDATE, and the PostgreSQL DELETE statements.
+// The first joined table becomes the FROM table, and its conditions are
+// returned separately, to be added to the WHERE clause
+func (b *Builder) joinToSqlAsFrom(joins []Join, method string) (from string, conditions string) {
+	first := joins[0]
+	firstType := lo.Ternary(first.Type == "", JOIN_TYPE_INNER, first.Type)
+
+	if firstType != JOIN_TYPE_INNER && firstType != JOIN_TYPE_CROSS {
+		b.fail(b.errUnsupportedFeature("in method " + method + "() a first join other than inner or cross join"))
+	}
+
+	from = b.joinTableToSql(first) + b.joinToSql(joins[1:])
+
+	if firstType == JOIN_TYPE_INNER {
+		conditions = b.joinConditionsToSql(first)
+	}
+
+	return from, conditions
+}
+
+// deleteJoinToSql converts a DELETE statement with joins to SQL
+func (b *Builder) deleteJoinToSql() string {
+	table := b.quoteTable(b.sqlTableName)
+
+	orderBy := ""
+	if len(b.sqlOrderBy) > 0 {
+		orderBy = b.orderByToSql(b.sqlOrderBy)
+	}
+
+	limit := ""
+	if b.sqlLimit > 0 {
+		limit = " LIMIT " + strconv.FormatInt(b.sqlLimit, 10)
+	}
+
+	offset := ""
+	if b.sqlOffset > 0 {
+		offset = " OFFSET " + strconv.FormatInt(b.sqlOffset, 10)
+	}
+
+	switch b.syntax() {
+	case DIALECT_MYSQL:
+		// MySQL does not support ORDER BY and LIMIT in a multiple-table DELETE
+		if orderBy != "" || limit != "" || offset != "" {
+			b.fail(b.errUnsupportedFeature("in method Delete() order by and limit with joins"))
+		}
+
+		return "DELETE " + table + " FROM " + table + b.joinToSql(b.sqlJoin) + b.whereToSql(b.sqlWhere) + b.returningToSql() + ";"
+	case DIALECT_MSSQL:
+		return "DELETE" + b.mssqlTopToSql("Delete") + " " + table + b.outputToSql("DELETED") + " FROM " + table + b.joinToSql(b.sqlJoin) + b.whereToSql(b.sqlWhere) + ";"
+	case DIALECT_POSTGRES:
+		// PostgreSQL does not support ORDER BY and LIMIT in DELETE
+		if orderBy != "" || limit != "" || offset != "" {
+			b.fail(b.errUnsupportedFeature("in method Delete() order by and limit with joins"))
+		}
+
+		using, conditions := b.joinToSqlAsFrom(b.sqlJoin, "Delete")
+		return "DELETE FROM " + table + " USING " + using + b.joinWhereToSql(conditions) + b.returningToSql() + ";"
+	case DIALECT_SQLITE:
+		// SQLite does not support joins in DELETE, the rows
+		// to delete are selected by rowid in a subquery
+		return "DELETE FROM " + table + " WHERE rowid IN (SELECT " + table + ".rowid FROM " + table + b.joinToSql(b.sqlJoin) + b.whereToSql(b.sqlWhere) + orderBy + limit + offset + ")" + b.returningToSql() + ";"
+	}
+
+	return ""
+}
+
+// joinWhereToSql combines the join conditions with the where
+// conditions of the builder into a WHERE clause
+func (b *Builder) joinWhereToSql(conditions string) string {
+	where := b.whereToSqlConditions(b.sqlWhere)
+
+	if conditions != "" && where != "" {
+		where = conditions + " AND (" + where + ")"
+	} else if conditions != "" {
+		where = conditions
+	}
+
+	if where == "" {
+		return ""
+	}
+
+	return " WHERE " + where
+}
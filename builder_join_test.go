@@ -0,0 +1,260 @@
+package sb
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBuilderJoinSelect(t *testing.T) {
+	tests := []struct {
+		dialect  string
+		expected string
+	}{
+		{DIALECT_MYSQL, "SELECT `users`.`id`, `o`.`total` FROM `users` INNER JOIN `orders` AS `o` ON `users`.`id` = `o`.`user_id` WHERE `o`.`total` > '100';"},
+		{DIALECT_POSTGRES, `SELECT "users"."id", "o"."total" FROM "users" INNER JOIN "orders" AS "o" ON "users"."id" = "o"."user_id" WHERE "o"."total" > '100';`},
+		{DIALECT_SQLITE, `SELECT "users"."id", "o"."total" FROM "users" INNER JOIN "orders" AS "o" ON "users"."id" = "o"."user_id" WHERE "o"."total" > '100';`},
+	}
+
+	for _, test := range tests {
+		sql := NewBuilder(test.dialect).
+			Table("users").
+			Join(Join{
+				Table: "orders",
+				Alias: "o",
+				On:    []JoinOn{{Column: "users.id", Operator: "=", OtherColumn: "o.user_id"}},
+			}).
+			Where(Where{Column: "o.total", Operator: ">", Value: "100"}).
+			Select([]string{"users.id", "o.total"})
+
+		if sql != test.expected {
+			t.Fatal("Expected:\n", test.expected, "\nbut found:\n", sql)
+		}
+	}
+}
+
+func TestBuilderJoinTypes(t *testing.T) {
+	sql := NewBuilder(DIALECT_POSTGRES).
+		Table("users").
+		LeftJoin(Join{Table: "orders", On: []JoinOn{{Column: "users.id", OtherColumn: "orders.user_id"}}}).
+		RightJoin(Join{Table: "payments", On: []JoinOn{{Column: "orders.id", OtherColumn: "payments.order_id"}}}).
+		FullJoin(Join{Table: "refunds", On: []JoinOn{{Column: "payments.id", OtherColumn: "refunds.payment_id"}}}).
+		CrossJoin(Join{Table: "currencies"}).
+		Select([]string{})
+
+	expected := `SELECT * FROM "users" LEFT JOIN "orders" ON "users"."id" = "orders"."user_id" RIGHT JOIN "payments" ON "orders"."id" = "payments"."order_id" FULL JOIN "refunds" ON "payments"."id" = "refunds"."payment_id" CROSS JOIN "currencies";`
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+}
+
+func TestBuilderJoinFullMysqlUnsupported(t *testing.T) {
+	_, _, err := NewBuilder(DIALECT_MYSQL).
+		Table("users").
+		FullJoin(Join{Table: "orders", Using: []string{"user_id"}}).
+		Build()
+
+	if !errors.Is(err, ErrUnsupportedFeature) {
+		t.Fatal("Error must be ErrUnsupportedFeature but got: ", err)
+	}
+}
+
+func TestBuilderJoinUsing(t *testing.T) {
+	sql := NewBuilder(DIALECT_SQLITE).
+		Table("users").
+		Join(Join{Table: "profiles", Using: []string{"user_id", "tenant_id"}}).
+		Select([]string{})
+
+	expected := `SELECT * FROM "users" INNER JOIN "profiles" USING ("user_id", "tenant_id");`
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+}
+
+func TestBuilderJoinWhere(t *testing.T) {
+	sql := NewBuilder(DIALECT_SQLITE).
+		Table("users").
+		LeftJoin(Join{
+			Table: "orders",
+			On:    []JoinOn{{Column: "users.id", OtherColumn: "orders.user_id"}},
+			Where: []Where{
+				{Column: "orders.status", Operator: "=", Value: "paid"},
+				{Column: "orders.status", Operator: "=", Value: "shipped", Type: "OR"},
+			},
+		}).
+		Select([]string{})
+
+	expected := `SELECT * FROM "users" LEFT JOIN "orders" ON "users"."id" = "orders"."user_id" AND ("orders"."status" = 'paid' OR "orders"."status" = 'shipped');`
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+}
+
+func TestBuilderJoinWithParams(t *testing.T) {
+	sql, params, err := NewBuilder(DIALECT_POSTGRES).
+		Table("users").
+		Join(Join{
+			Table: "orders",
+			On:    []JoinOn{{Column: "users.id", OtherColumn: "orders.user_id"}},
+			Where: []Where{{Column: "orders.status", Operator: "=", Value: "paid"}},
+		}).
+		Where(Where{Column: "users.status", Operator: "=", Value: "active"}).
+		SelectWithParams([]string{"users.id"})
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	expected := `SELECT "users"."id" FROM "users" INNER JOIN "orders" ON "users"."id" = "orders"."user_id" AND ("orders"."status" = $1) WHERE "users"."status" = $2;`
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+
+	if len(params) != 2 || params[0] != "paid" || params[1] != "active" {
+		t.Fatal("Unexpected params: ", params)
+	}
+}
+
+func TestBuilderJoinUpdate(t *testing.T) {
+	tests := []struct {
+		dialect  string
+		expected string
+	}{
+		{DIALECT_MYSQL, "UPDATE `users` INNER JOIN `orders` ON `users`.`id` = `orders`.`user_id` SET `status`='buyer' WHERE `orders`.`total` > '0';"},
+		{DIALECT_POSTGRES, `UPDATE "users" SET "status"='buyer' FROM "orders" WHERE "users"."id" = "orders"."user_id" AND ("orders"."total" > '0');`},
+		{DIALECT_SQLITE, `UPDATE "users" SET "status"='buyer' FROM "orders" WHERE "users"."id" = "orders"."user_id" AND ("orders"."total" > '0');`},
+	}
+
+	for _, test := range tests {
+		sql := NewBuilder(test.dialect).
+			Table("users").
+			Join(Join{Table: "orders", On: []JoinOn{{Column: "users.id", OtherColumn: "orders.user_id"}}}).
+			Where(Where{Column: "orders.total", Operator: ">", Value: "0"}).
+			Update(map[string]string{"status": "buyer"})
+
+		if sql != test.expected {
+			t.Fatal("Expected:\n", test.expected, "\nbut found:\n", sql)
+		}
+	}
+}
+
+func TestBuilderJoinDelete(t *testing.T) {
+	tests := []struct {
+		dialect  string
+		expected string
+	}{
+		{DIALECT_MYSQL, "DELETE `users` FROM `users` INNER JOIN `bans` ON `users`.`id` = `bans`.`user_id`;"},
+		{DIALECT_POSTGRES, `DELETE FROM "users" USING "bans" WHERE "users"."id" = "bans"."user_id";`},
+		{DIALECT_SQLITE, `DELETE FROM "users" WHERE rowid IN (SELECT "users".rowid FROM "users" INNER JOIN "bans" ON "users"."id" = "bans"."user_id");`},
+	}
+
+	for _, test := range tests {
+		sql := NewBuilder(test.dialect).
+			Table("users").
+			Join(Join{Table: "bans", On: []JoinOn{{Column: "users.id", OtherColumn: "bans.user_id"}}}).
+			Delete()
+
+		if sql != test.expected {
+			t.Fatal("Expected:\n", test.expected, "\nbut found:\n", sql)
+		}
+	}
+}
+
+func TestBuilderJoinDeleteMysqlLimitUnsupported(t *testing.T) {
+	_, _, err := NewBuilder(DIALECT_MYSQL).
+		Table("users").
+		Join(Join{Table: "bans", On: []JoinOn{{Column: "users.id", OtherColumn: "bans.user_id"}}}).
+		OrderBy("users.id", "asc").
+		Limit(5).
+		DeleteWithParams()
+
+	if !errors.Is(err, ErrUnsupportedFeature) {
+		t.Fatal("Expected ErrUnsupportedFeature but found: ", err)
+	}
+}
+
+func TestBuilderJoinDeletePostgresLimitUnsupported(t *testing.T) {
+	builders := []BuilderInterface{
+		NewBuilder(DIALECT_POSTGRES).Table("users").OrderBy("id", "asc"),
+		NewBuilder(DIALECT_POSTGRES).Table("users").Limit(2),
+		NewBuilder(DIALECT_POSTGRES).Table("users").Offset(2),
+	}
+
+	for _, builder := range builders {
+		_, _, err := builder.
+			Join(Join{Table: "orders", Alias: "o", On: []JoinOn{{Column: "users.id", OtherColumn: "o.user_id"}}}).
+			DeleteWithParams()
+
+		if !errors.Is(err, ErrUnsupportedFeature) {
+			t.Fatal("Expected ErrUnsupportedFeature but found: ", err)
+		}
+	}
+}
+
+func TestBuilderJoinRightSqliteVersion(t *testing.T) {
+	_, _, err := NewBuilder(DIALECT_SQLITE).
+		Version("3.38.5").
+		Table("users").
+		RightJoin(Join{Table: "orders", On: []JoinOn{{Column: "users.id", OtherColumn: "orders.user_id"}}}).
+		SelectWithParams([]string{"users.id"})
+
+	if !errors.Is(err, ErrUnsupportedFeature) {
+		t.Fatal("Expected ErrUnsupportedFeature but found: ", err)
+	}
+
+	_, _, err = NewBuilder(DIALECT_SQLITE).
+		Version("3.39.0").
+		Table("users").
+		RightJoin(Join{Table: "orders", On: []JoinOn{{Column: "users.id", OtherColumn: "orders.user_id"}}}).
+		SelectWithParams([]string{"users.id"})
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+}
+
+func TestBuilderJoinSqliteExec(t *testing.T) {
+	db, err := initSqliteDB("test_builder_join.db")
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	defer db.Close()
+
+	statements := []string{
+		NewBuilder(DIALECT_SQLITE).Table("users").
+			Column(Column{Name: "id", Type: COLUMN_TYPE_STRING, PrimaryKey: true}).
+			Column(Column{Name: "status", Type: COLUMN_TYPE_STRING}).
+			Create(),
+		NewBuilder(DIALECT_SQLITE).Table("bans").
+			Column(Column{Name: "user_id", Type: COLUMN_TYPE_STRING}).
+			Create(),
+		NewBuilder(DIALECT_SQLITE).Table("users").Insert(map[string]string{"id": "1", "status": "active"}),
+		NewBuilder(DIALECT_SQLITE).Table("users").Insert(map[string]string{"id": "2", "status": "active"}),
+		NewBuilder(DIALECT_SQLITE).Table("bans").Insert(map[string]string{"user_id": "2"}),
+		NewBuilder(DIALECT_SQLITE).Table("users").
+			LeftJoin(Join{Table: "bans", On: []JoinOn{{Column: "users.id", OtherColumn: "bans.user_id"}}}).
+			Where(Where{Column: "bans.user_id", Operator: "=", Value: "NULL"}).
+			Delete(),
+	}
+
+	for _, statement := range statements {
+		_, err = db.Exec(statement)
+		if err != nil {
+			t.Fatal("Error must be NIL but got: ", err.Error(), " for ", statement)
+		}
+	}
+
+	rows, err := db.SelectToMapString(NewBuilder(DIALECT_SQLITE).
+		Table("users").
+		Join(Join{Table: "bans", On: []JoinOn{{Column: "users.id", OtherColumn: "bans.user_id"}}}).
+		Select([]string{"users.id"}))
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	if len(rows) != 1 || rows[0]["id"] != "2" {
+		t.Fatal("Expected only the banned user to remain but got: ", rows)
+	}
+}
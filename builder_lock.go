@@ -0,0 +1,120 @@
+package sb
+
+import (
+	"strings"
+)
+
+// Lock represents the row locking of a select, used to claim
+// rows inside a transaction
+//
+//	Example:
+//	// SELECT * FROM "jobs" WHERE "status" = 'pending' LIMIT 10 FOR UPDATE SKIP LOCKED;
+//	NewBuilder(DIALECT_POSTGRES).
+//		Table("jobs").
+//		Where(Where{Column: "status", Operator: "=", Value: "pending"}).
+//		Limit(10).
+//		Lock(Lock{Mode: LOCK_FOR_UPDATE, SkipLocked: true}).
+//		Select([]string{})
+type Lock struct {
+	// Mode is the lock mode, one of the LOCK_FOR_* constants
+	Mode string
+
+	// SkipLocked skips the rows locked by other transactions
+	SkipLocked bool
+
+	// NoWait fails instead of waiting for the rows locked by other transactions
+	NoWait bool
+}
+
+// Lock locks the selected rows until the end of the transaction.
+// It is rendered as FOR UPDATE (or FOR SHARE) for PostgreSQL and MySQL 8,
+// and as the UPDLOCK (or HOLDLOCK) table hint for MSSQL, with READPAST
+// to skip the locked rows. SQLite has no row locks, as a write transaction
+// locks the whole database, so there it is a no-op
+func (b *Builder) Lock(lock Lock) BuilderInterface {
+	lock.Mode = strings.ToUpper(lock.Mode)
+
+	if lock.Mode != LOCK_FOR_UPDATE && lock.Mode != LOCK_FOR_SHARE {
+		return b.errorAdd(errInvalidQuery("lock mode must be " + LOCK_FOR_UPDATE + " or " + LOCK_FOR_SHARE))
+	}
+
+	if lock.SkipLocked && lock.NoWait {
+		return b.errorAdd(errInvalidQuery("lock cannot both skip the locked rows and not wait for them"))
+	}
+
+	b = b.mutable()
+	b.sqlLock = &lock
+
+	return b
+}
+
+// lockToSql converts the lock to the locking clause
+// at the end of the select (PostgreSQL and MySQL)
+func (b *Builder) lockToSql() string {
+	if b.sqlLock == nil || (b.syntax() != DIALECT_POSTGRES && b.syntax() != DIALECT_MYSQL) {
+		return ""
+	}
+
+	// The locked rows must map to the rows of the table, which
+	// they do not after a union, a distinct or a group by
+	if len(b.sqlCompound) > 0 {
+		b.fail(b.errUnsupportedFeature("lock of a union, intersect or except"))
+	}
+
+	if b.sqlDistinct || len(b.sqlDistinctOn) > 0 {
+		b.fail(b.errUnsupportedFeature("lock with distinct"))
+	}
+
+	if len(b.sqlGroupBy) > 0 || len(b.sqlHaving) > 0 {
+		b.fail(b.errUnsupportedFeature("lock with group by or having"))
+	}
+
+	if b.sqlLock.Mode == LOCK_FOR_SHARE && !b.supports(FEATURE_FOR_SHARE) {
+		b.fail(b.errUnsupportedFeature("lock for share"))
+	}
+
+	sql := " FOR " + b.sqlLock.Mode
+
+	if b.sqlLock.SkipLocked {
+		if !b.supports(FEATURE_SKIP_LOCKED) {
+			b.fail(b.errUnsupportedFeature("lock skip locked"))
+		}
+		sql += " SKIP LOCKED"
+	}
+
+	if b.sqlLock.NoWait {
+		if !b.supports(FEATURE_NOWAIT) {
+			b.fail(b.errUnsupportedFeature("lock nowait"))
+		}
+		sql += " NOWAIT"
+	}
+
+	return sql
+}
+
+// lockHintToSql converts the lock to the table hint
+// after the selected table (MSSQL)
+func (b *Builder) lockHintToSql() string {
+	if b.sqlLock == nil || b.syntax() != DIALECT_MSSQL {
+		return ""
+	}
+
+	if b.sqlFromSubquery != nil {
+		b.fail(b.errUnsupportedFeature("lock of a subquery"))
+	}
+
+	hints := []string{"UPDLOCK", "ROWLOCK"}
+	if b.sqlLock.Mode == LOCK_FOR_SHARE {
+		hints = []string{"HOLDLOCK", "ROWLOCK"}
+	}
+
+	if b.sqlLock.SkipLocked {
+		hints = append(hints, "READPAST")
+	}
+
+	if b.sqlLock.NoWait {
+		hints = append(hints, "NOWAIT")
+	}
+
+	return " WITH (" + strings.Join(hints, ", ") + ")"
+}
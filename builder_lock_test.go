@@ -0,0 +1,183 @@
+package sb
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBuilderLock(t *testing.T) {
+	tests := []struct {
+		dialect  string
+		lock     Lock
+		expected string
+	}{
+		{DIALECT_POSTGRES, Lock{Mode: LOCK_FOR_UPDATE}, `SELECT "id" FROM "jobs" WHERE "status" = 'pending' ORDER BY "id" ASC LIMIT 10 FOR UPDATE;`},
+		{DIALECT_POSTGRES, Lock{Mode: LOCK_FOR_UPDATE, SkipLocked: true}, `SELECT "id" FROM "jobs" WHERE "status" = 'pending' ORDER BY "id" ASC LIMIT 10 FOR UPDATE SKIP LOCKED;`},
+		{DIALECT_POSTGRES, Lock{Mode: "share", NoWait: true}, `SELECT "id" FROM "jobs" WHERE "status" = 'pending' ORDER BY "id" ASC LIMIT 10 FOR SHARE NOWAIT;`},
+		{DIALECT_MYSQL, Lock{Mode: LOCK_FOR_UPDATE, SkipLocked: true}, "SELECT `id` FROM `jobs` WHERE `status` = 'pending' ORDER BY `id` ASC LIMIT 10 FOR UPDATE SKIP LOCKED;"},
+		{DIALECT_MYSQL, Lock{Mode: LOCK_FOR_SHARE}, "SELECT `id` FROM `jobs` WHERE `status` = 'pending' ORDER BY `id` ASC LIMIT 10 FOR SHARE;"},
+		{DIALECT_MSSQL, Lock{Mode: LOCK_FOR_UPDATE, SkipLocked: true}, `SELECT TOP 10 [id] FROM [jobs] WITH (UPDLOCK, ROWLOCK, READPAST) WHERE [status] = N'pending' ORDER BY [id] ASC;`},
+		{DIALECT_MSSQL, Lock{Mode: LOCK_FOR_SHARE, NoWait: true}, `SELECT TOP 10 [id] FROM [jobs] WITH (HOLDLOCK, ROWLOCK, NOWAIT) WHERE [status] = N'pending' ORDER BY [id] ASC;`},
+		{DIALECT_SQLITE, Lock{Mode: LOCK_FOR_UPDATE, SkipLocked: true}, `SELECT "id" FROM "jobs" WHERE "status" = 'pending' ORDER BY "id" ASC LIMIT 10;`},
+	}
+
+	for _, test := range tests {
+		sql := NewBuilder(test.dialect).
+			Table("jobs").
+			Where(Where{Column: "status", Operator: "=", Value: "pending"}).
+			OrderBy("id", ASC).
+			Limit(10).
+			Lock(test.lock).
+			Select([]string{"id"})
+
+		if sql != test.expected {
+			t.Fatal("Expected:\n", test.expected, "\nbut found:\n", sql)
+		}
+	}
+}
+
+func TestBuilderLockJoinMssql(t *testing.T) {
+	sql := NewBuilder(DIALECT_MSSQL).
+		Table("jobs").
+		Join(Join{Table: "queues", On: []JoinOn{{Column: "jobs.queue_id", OtherColumn: "queues.id"}}}).
+		Lock(Lock{Mode: LOCK_FOR_UPDATE}).
+		Select([]string{"jobs.id"})
+
+	expected := `SELECT [jobs].[id] FROM [jobs] WITH (UPDLOCK, ROWLOCK) INNER JOIN [queues] ON [jobs].[queue_id] = [queues].[id];`
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+}
+
+func TestBuilderLockInvalid(t *testing.T) {
+	tests := []Lock{
+		{Mode: "EXCLUSIVE"},
+		{Mode: LOCK_FOR_UPDATE, SkipLocked: true, NoWait: true},
+	}
+
+	for _, lock := range tests {
+		err := NewBuilder(DIALECT_POSTGRES).Table("jobs").Lock(lock).Err()
+
+		if !errors.Is(err, ErrInvalidQuery) {
+			t.Fatal("Error must be ErrInvalidQuery for ", lock, " but got: ", err)
+		}
+	}
+}
+
+func TestBuilderLockUnsupported(t *testing.T) {
+	for _, dialect := range []string{DIALECT_MYSQL, DIALECT_POSTGRES} {
+		builders := map[string]BuilderInterface{
+			"union":       NewBuilder(dialect).Table("a").Union(Subquery{Query: NewBuilder(dialect).Table("b")}),
+			"distinct":    NewBuilder(dialect).Table("a").Distinct(),
+			"group by":    NewBuilder(dialect).Table("a").GroupBy(GroupBy{Column: "x"}),
+			"having":      NewBuilder(dialect).Table("a").Having(Where{Column: "x", Operator: ">", Value: "1"}),
+			"distinct on": NewBuilder(dialect).Table("a").DistinctOn("x"),
+		}
+
+		for name, builder := range builders {
+			if name == "distinct on" && dialect != DIALECT_POSTGRES {
+				continue
+			}
+
+			_, _, err := builder.Lock(Lock{Mode: LOCK_FOR_UPDATE}).SelectColumns("x").Build()
+
+			if !errors.Is(err, ErrUnsupportedFeature) {
+				t.Fatal("Expected ErrUnsupportedFeature for the lock with ", name, " for ", dialect, " but found: ", err)
+			}
+		}
+	}
+}
+
+func TestBuilderLockVersion(t *testing.T) {
+	tests := []struct {
+		dialect string
+		version string
+		lock    Lock
+	}{
+		{DIALECT_MYSQL, "5.7.44", Lock{Mode: LOCK_FOR_SHARE}},
+		{DIALECT_MYSQL, "5.7.44", Lock{Mode: LOCK_FOR_UPDATE, SkipLocked: true}},
+		{DIALECT_MYSQL, "5.7.44", Lock{Mode: LOCK_FOR_UPDATE, NoWait: true}},
+		{DIALECT_POSTGRES, "9.4.26", Lock{Mode: LOCK_FOR_UPDATE, SkipLocked: true}},
+	}
+
+	for _, test := range tests {
+		_, _, err := NewBuilder(test.dialect).
+			Table("jobs").
+			Lock(test.lock).
+			Version(test.version).
+			Build()
+
+		if !errors.Is(err, ErrUnsupportedFeature) {
+			t.Fatal("Expected ErrUnsupportedFeature for ", test.dialect, " ", test.version, " ", test.lock, " but found: ", err)
+		}
+	}
+
+	sql, _, err := NewBuilder(DIALECT_MYSQL).
+		Version("5.7.44").
+		Table("jobs").
+		Lock(Lock{Mode: LOCK_FOR_UPDATE}).
+		Build()
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	expected := "SELECT * FROM `jobs` FOR UPDATE;"
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+
+	sql, _, err = NewBuilder(DIALECT_POSTGRES).
+		Version("9.4.26").
+		Table("jobs").
+		Lock(Lock{Mode: LOCK_FOR_SHARE, NoWait: true}).
+		Build()
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	expected = `SELECT * FROM "jobs" FOR SHARE NOWAIT;`
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+}
+
+func TestBuilderLockSqliteExec(t *testing.T) {
+	db, err := initSqliteDB("test_builder_lock.db")
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	defer db.Close()
+
+	_, err = db.Exec(NewBuilder(DIALECT_SQLITE).Table("jobs").
+		Column(Column{Name: "id", Type: COLUMN_TYPE_INTEGER, PrimaryKey: true}).
+		Create())
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	err = db.BeginTransaction()
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	_, err = db.Query(NewBuilder(DIALECT_SQLITE).
+		Table("jobs").
+		Lock(Lock{Mode: LOCK_FOR_UPDATE, SkipLocked: true}).
+		Select([]string{"id"}))
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	err = db.RollbackTransaction()
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+}
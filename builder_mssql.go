@@ -0,0 +1,40 @@
+package sb
+
+import (
+	"strconv"
+)
+
+// MSSQL object types, as used by OBJECT_ID
+const (
+	mssqlObjectTypeTable = "U"
+	mssqlObjectTypeView  = "V"
+)
+
+// mssqlObjectIdToSql converts a table or view name to an OBJECT_ID call,
+// used to check if the object exists, i.e. OBJECT_ID(N'[users]', N'U')
+func (b *Builder) mssqlObjectIdToSql(name string, objectType string) string {
+	return "OBJECT_ID(" + b.mssqlStringToSql(b.quoteTable(name)) + ", " + b.mssqlStringToSql(objectType) + ")"
+}
+
+// mssqlStringToSql converts a string to a MSSQL unicode string literal.
+// Unlike quoteValue, it is never replaced by a parameter, so it can be
+// used for the names of the objects
+func (b *Builder) mssqlStringToSql(value string) string {
+	quoted, _ := MSSQLDialect{}.QuoteString(value)
+	return quoted
+}
+
+// mssqlTopToSql converts the limit of an UPDATE or DELETE statement
+// to a TOP clause. MSSQL does not support ordering or skipping rows
+// in these statements
+func (b *Builder) mssqlTopToSql(method string) string {
+	if len(b.sqlOrderBy) > 0 || b.sqlOffset > 0 {
+		b.fail(b.errUnsupportedFeature("in method " + method + "() order by and offset"))
+	}
+
+	if b.sqlLimit <= 0 {
+		return ""
+	}
+
+	return " TOP (" + strconv.FormatInt(b.sqlLimit, 10) + ")"
+}
@@ -0,0 +1,259 @@
+package sb
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBuilderMssqlQuote(t *testing.T) {
+	sql := NewBuilder(DIALECT_MSSQL).
+		Table("odd]table").
+		Where(Where{Column: "name", Operator: "=", Value: "O'Brien"}).
+		Select([]string{"id", "users.name"})
+
+	expected := `SELECT [id], [users].[name] FROM [odd]]table] WHERE [name] = N'O''Brien';`
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+}
+
+func TestBuilderMssqlSelect(t *testing.T) {
+	tests := []struct {
+		builder  BuilderInterface
+		expected string
+	}{
+		{
+			NewBuilder(DIALECT_MSSQL).Table("users").Where(Where{Column: "status", Operator: "=", Value: "active"}).OrderBy("name", ASC).Limit(10),
+			`SELECT TOP 10 [id], [name] FROM [users] WHERE [status] = N'active' ORDER BY [name] ASC;`,
+		},
+		{
+			NewBuilder(DIALECT_MSSQL).Table("users").OrderBy("name", DESC).Limit(10).Offset(20),
+			`SELECT [id], [name] FROM [users] ORDER BY [name] DESC OFFSET 20 ROWS FETCH NEXT 10 ROWS ONLY;`,
+		},
+		{
+			NewBuilder(DIALECT_MSSQL).Table("users").Offset(20),
+			`SELECT [id], [name] FROM [users] ORDER BY (SELECT NULL) OFFSET 20 ROWS;`,
+		},
+		{
+			NewBuilder(DIALECT_MSSQL).Table("users").GroupBy(GroupBy{Column: "id"}).GroupBy(GroupBy{Column: "name"}),
+			`SELECT [id], [name] FROM [users] GROUP BY [id],[name];`,
+		},
+	}
+
+	for _, test := range tests {
+		sql := test.builder.Select([]string{"id", "name"})
+
+		if sql != test.expected {
+			t.Fatal("Expected:\n", test.expected, "\nbut found:\n", sql)
+		}
+	}
+}
+
+func TestBuilderMssqlSelectWithParams(t *testing.T) {
+	sql, params, err := NewBuilder(DIALECT_MSSQL).
+		Table("users").
+		Where(Where{Column: "status", Operator: "=", Value: "active"}).
+		Where(Where{Column: "id", Operator: OPERATOR_IN, Values: []any{1, 2}}).
+		SelectWithParams([]string{})
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	expected := `SELECT * FROM [users] WHERE [status] = @p1 AND [id] IN (@p2, @p3);`
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+
+	if len(params) != 3 || params[0] != "active" || params[1] != 1 || params[2] != 2 {
+		t.Fatal("Unexpected params: ", params)
+	}
+}
+
+func TestBuilderMssqlInsertUpdateDelete(t *testing.T) {
+	tests := []struct {
+		sql      string
+		expected string
+	}{
+		{
+			NewBuilder(DIALECT_MSSQL).Table("users").Insert(map[string]string{"name": "Tom", "status": "active"}),
+			`INSERT INTO [users] ([name], [status]) VALUES (N'Tom', N'active');`,
+		},
+		{
+			NewBuilder(DIALECT_MSSQL).Table("users").Where(Where{Column: "id", Operator: "=", Value: "1"}).Update(map[string]string{"name": "Tom"}),
+			`UPDATE [users] SET [name]=N'Tom' WHERE [id] = N'1';`,
+		},
+		{
+			NewBuilder(DIALECT_MSSQL).Table("users").Where(Where{Column: "status", Operator: "=", Value: "new"}).Limit(100).Update(map[string]string{"status": "old"}),
+			`UPDATE TOP (100) [users] SET [status]=N'old' WHERE [status] = N'new';`,
+		},
+		{
+			NewBuilder(DIALECT_MSSQL).Table("users").Join(Join{Table: "orders", On: []JoinOn{{Column: "users.id", OtherColumn: "orders.user_id"}}}).Update(map[string]string{"status": "buyer"}),
+			`UPDATE [users] SET [status]=N'buyer' FROM [users] INNER JOIN [orders] ON [users].[id] = [orders].[user_id];`,
+		},
+		{
+			NewBuilder(DIALECT_MSSQL).Table("users").Where(Where{Column: "id", Operator: "=", Value: "1"}).Delete(),
+			`DELETE FROM [users] WHERE [id] = N'1';`,
+		},
+		{
+			NewBuilder(DIALECT_MSSQL).Table("logs").Limit(1000).Delete(),
+			`DELETE TOP (1000) FROM [logs];`,
+		},
+		{
+			NewBuilder(DIALECT_MSSQL).Table("users").Join(Join{Table: "bans", On: []JoinOn{{Column: "users.id", OtherColumn: "bans.user_id"}}}).Limit(10).Delete(),
+			`DELETE TOP (10) [users] FROM [users] INNER JOIN [bans] ON [users].[id] = [bans].[user_id];`,
+		},
+	}
+
+	for _, test := range tests {
+		if test.sql != test.expected {
+			t.Fatal("Expected:\n", test.expected, "\nbut found:\n", test.sql)
+		}
+	}
+}
+
+func TestBuilderMssqlInsertLimitUnsupported(t *testing.T) {
+	builders := []BuilderInterface{
+		NewBuilder(DIALECT_MSSQL).Table("users").Limit(1),
+		NewBuilder(DIALECT_MSSQL).Table("users").Offset(1),
+	}
+
+	for _, builder := range builders {
+		_, err := builder.InsertSql(map[string]string{"a": "b"})
+
+		if !errors.Is(err, ErrUnsupportedFeature) {
+			t.Fatal("Expected ErrUnsupportedFeature but found: ", err)
+		}
+	}
+}
+
+func TestBuilderMssqlDeleteOrderByPanics(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("Delete with order by must panic for MSSQL")
+		}
+	}()
+
+	NewBuilder(DIALECT_MSSQL).
+		Table("logs").
+		OrderBy("created_at", ASC).
+		Limit(10).
+		Delete()
+}
+
+func TestBuilderMssqlTable(t *testing.T) {
+	tests := []struct {
+		sql      string
+		expected string
+	}{
+		{
+			NewBuilder(DIALECT_MSSQL).Table("users").Column(Column{Name: "id", Type: COLUMN_TYPE_INTEGER, PrimaryKey: true, AutoIncrement: true}).CreateIfNotExists(),
+			`IF OBJECT_ID(N'[users]', N'U') IS NULL CREATE TABLE [users] ([id] INTEGER IDENTITY(1,1) PRIMARY KEY NOT NULL);`,
+		},
+		{
+			NewBuilder(DIALECT_MSSQL).Table("users").Drop(),
+			`DROP TABLE [users];`,
+		},
+		{
+			NewBuilder(DIALECT_MSSQL).Table("users").DropIfExists(),
+			`IF OBJECT_ID(N'[users]', N'U') IS NOT NULL DROP TABLE [users];`,
+		},
+	}
+
+	for _, test := range tests {
+		if test.sql != test.expected {
+			t.Fatal("Expected:\n", test.expected, "\nbut found:\n", test.sql)
+		}
+	}
+}
+
+func TestBuilderMssqlView(t *testing.T) {
+	selectSQL := NewBuilder(DIALECT_MSSQL).
+		Table("users").
+		Where(Where{Column: "status", Operator: "=", Value: "active"}).
+		Select([]string{"id", "name"})
+
+	tests := []struct {
+		sql      string
+		expected string
+	}{
+		{
+			NewBuilder(DIALECT_MSSQL).View("v_users").ViewColumns([]string{"id", "name"}).ViewSQL(selectSQL).Create(),
+			`CREATE VIEW [v_users] ([id], [name]) AS SELECT [id], [name] FROM [users] WHERE [status] = N'active';`,
+		},
+		{
+			NewBuilder(DIALECT_MSSQL).View("v_users").ViewSQL(selectSQL).CreateIfNotExists(),
+			`IF OBJECT_ID(N'[v_users]', N'V') IS NULL EXEC(N'CREATE VIEW [v_users] AS SELECT [id], [name] FROM [users] WHERE [status] = N''active'';');`,
+		},
+		{
+			NewBuilder(DIALECT_MSSQL).View("v_users").Drop(),
+			`DROP VIEW [v_users];`,
+		},
+		{
+			NewBuilder(DIALECT_MSSQL).View("v_users").DropIfExists(),
+			`IF OBJECT_ID(N'[v_users]', N'V') IS NOT NULL DROP VIEW [v_users];`,
+		},
+	}
+
+	for _, test := range tests {
+		if test.sql != test.expected {
+			t.Fatal("Expected:\n", test.expected, "\nbut found:\n", test.sql)
+		}
+	}
+}
+
+func TestBuilderMssqlRename(t *testing.T) {
+	sql, err := NewBuilder(DIALECT_MSSQL).TableRename("users", "customers")
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	expected := `EXEC sp_rename N'[users]', N'customers', 'OBJECT';`
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+
+	sql, err = NewBuilder(DIALECT_MSSQL).TableColumnRename("users", "name", "full_name")
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	expected = `EXEC sp_rename N'[users].[name]', N'full_name', 'COLUMN';`
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+}
+
+func TestBuilderMssqlWith(t *testing.T) {
+	sql := NewBuilder(DIALECT_MSSQL).
+		WithRecursive("tree",
+			NewBuilder(DIALECT_MSSQL).Table("categories").Where(Where{Column: "id", Operator: "=", Values: []any{1}}).SelectColumns("id"),
+			NewBuilder(DIALECT_MSSQL).Table("categories").Join(Join{
+				Table: "tree",
+				On:    []JoinOn{{Column: "categories.parent_id", OtherColumn: "tree.id"}},
+			}).SelectColumns("categories.id"),
+		).
+		Table("tree").
+		Select([]string{"id"})
+
+	expected := `WITH [tree] AS (SELECT [id] FROM [categories] WHERE [id] = 1 UNION ALL SELECT [categories].[id] FROM [categories] INNER JOIN [tree] ON [categories].[parent_id] = [tree].[id]) SELECT [id] FROM [tree];`
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+}
+
+func TestBuilderMssqlCompound(t *testing.T) {
+	sql := NewBuilder(DIALECT_MSSQL).
+		Table("customers").
+		Union(Subquery{Query: NewBuilder(DIALECT_MSSQL).Table("suppliers"), Columns: []string{"name"}}).
+		OrderBy("name", ASC).
+		Limit(10).
+		Select([]string{"name"})
+
+	expected := `(SELECT [name] FROM [customers]) UNION (SELECT [name] FROM [suppliers]) ORDER BY [name] ASC OFFSET 0 ROWS FETCH NEXT 10 ROWS ONLY;`
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+}
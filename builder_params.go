@@ -0,0 +1,93 @@
+package sb
+
+// SelectWithParams works like Select, but instead of inlining the values
+// it returns them as bound parameters, together with the SQL that uses
+// dialect specific placeholders (? for MySQL and SQLite, $1..$n for
+// PostgreSQL, @p1..@pn for MSSQL)
+//
+//	Example:
+//	sql, params, err := NewBuilder(DIALECT_POSTGRES).
+//		Table("users").
+//		Where(Where{Column: "id", Operator: "=", Value: "1"}).
+//		SelectWithParams([]string{"id", "name"})
+//	// sql: SELECT "id", "name" FROM "users" WHERE "id" = $1;
+//	// params: []any{"1"}
+func (b *Builder) SelectWithParams(columns []string) (sql string, params []any, err error) {
+	defer recoverError(&err)
+
+	if b.sqlTableName == "" && b.sqlFromSubquery == nil {
+		return "", nil, errNoTable("SelectWithParams")
+	}
+
+	sql, params = b.withParams(func(b *Builder) string {
+		return b.Select(columns)
+	})
+
+	return sql, params, nil
+}
+
+// InsertWithParams works like Insert, but returns the values as bound parameters
+func (b *Builder) InsertWithParams(columnValuesMap map[string]string) (sql string, params []any, err error) {
+	defer recoverError(&err)
+
+	if b.sqlTableName == "" {
+		return "", nil, errNoTable("InsertWithParams")
+	}
+
+	sql, params = b.withParams(func(b *Builder) string {
+		return b.Insert(columnValuesMap)
+	})
+
+	return sql, params, nil
+}
+
+// UpdateWithParams works like Update, but returns the values as bound parameters
+func (b *Builder) UpdateWithParams(columnValues map[string]string) (sql string, params []any, err error) {
+	defer recoverError(&err)
+
+	if b.sqlTableName == "" {
+		return "", nil, errNoTable("UpdateWithParams")
+	}
+
+	sql, params = b.withParams(func(b *Builder) string {
+		return b.Update(columnValues)
+	})
+
+	return sql, params, nil
+}
+
+// DeleteWithParams works like Delete, but returns the values as bound parameters
+func (b *Builder) DeleteWithParams() (sql string, params []any, err error) {
+	defer recoverError(&err)
+
+	if b.sqlTableName == "" {
+		return "", nil, errNoTable("DeleteWithParams")
+	}
+
+	sql, params = b.withParams(func(b *Builder) string {
+		return b.Delete()
+	})
+
+	return sql, params, nil
+}
+
+// withParams runs the build function with parameter collection enabled,
+// and returns the generated SQL with the collected parameters. The build
+// function gets a copy of the builder collecting the parameters, so the
+// builder itself is not changed, and can be converted concurrently
+func (b *Builder) withParams(build func(b *Builder) string) (sql string, params []any) {
+	collector := *b
+	collector.sqlParamsEnabled = true
+	collector.sqlParams = []any{}
+
+	sql = build(&collector)
+
+	return sql, collector.sqlParams
+}
+
+// paramAdd adds a value to the collected parameters, and returns
+// the placeholder to be used in its place
+func (b *Builder) paramAdd(value any) string {
+	b.sqlParams = append(b.sqlParams, value)
+	return b.dialectOrFail().Placeholder(len(b.sqlParams))
+}
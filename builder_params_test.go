@@ -0,0 +1,202 @@
+package sb
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBuilderSelectWithParams(t *testing.T) {
+	tests := []struct {
+		dialect  string
+		expected string
+	}{
+		{DIALECT_MYSQL, "SELECT `id` FROM `users` WHERE `first_name` = ? AND `last_name` <> ?;"},
+		{DIALECT_POSTGRES, `SELECT "id" FROM "users" WHERE "first_name" = $1 AND "last_name" <> $2;`},
+		{DIALECT_SQLITE, `SELECT "id" FROM "users" WHERE "first_name" = ? AND "last_name" <> ?;`},
+	}
+
+	for _, test := range tests {
+		sql, params, err := NewBuilder(test.dialect).
+			Table("users").
+			Where(Where{Column: "first_name", Operator: "==", Value: "Tom"}).
+			Where(Where{Column: "last_name", Operator: "!=", Value: "Jones"}).
+			SelectWithParams([]string{"id"})
+
+		if err != nil {
+			t.Fatal("Error must be NIL but got: ", err.Error())
+		}
+
+		if sql != test.expected {
+			t.Fatal("Expected:\n", test.expected, "\nbut found:\n", sql)
+		}
+
+		if !reflect.DeepEqual(params, []any{"Tom", "Jones"}) {
+			t.Fatal("Unexpected params: ", params)
+		}
+	}
+}
+
+func TestBuilderSelectWithParamsNull(t *testing.T) {
+	sql, params, err := NewBuilder(DIALECT_POSTGRES).
+		Table("users").
+		Where(Where{Column: "deleted_at", Operator: "=", Value: "NULL"}).
+		SelectWithParams([]string{})
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	expected := `SELECT * FROM "users" WHERE "deleted_at" IS NULL;`
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+
+	if len(params) != 0 {
+		t.Fatal("Params must be empty but got: ", params)
+	}
+}
+
+func TestBuilderSelectWithParamsNoTable(t *testing.T) {
+	_, _, err := NewBuilder(DIALECT_SQLITE).SelectWithParams([]string{})
+
+	if err == nil {
+		t.Fatal("Error must NOT be NIL")
+	}
+}
+
+func TestBuilderInsertWithParams(t *testing.T) {
+	sql, params, err := NewBuilder(DIALECT_POSTGRES).
+		Table("users").
+		InsertWithParams(map[string]string{
+			"first_name": "Tom",
+			"last_name":  "Jones",
+		})
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	expected := `INSERT INTO "users" ("first_name", "last_name") VALUES ($1, $2);`
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+
+	if !reflect.DeepEqual(params, []any{"Tom", "Jones"}) {
+		t.Fatal("Unexpected params: ", params)
+	}
+}
+
+func TestBuilderUpdateWithParams(t *testing.T) {
+	sql, params, err := NewBuilder(DIALECT_POSTGRES).
+		Table("users").
+		Where(Where{Column: "id", Operator: "==", Value: "1"}).
+		UpdateWithParams(map[string]string{
+			"first_name": "Tom",
+			"last_name":  "Jones",
+		})
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	expected := `UPDATE "users" SET "first_name"=$1, "last_name"=$2 WHERE "id" = $3;`
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+
+	if !reflect.DeepEqual(params, []any{"Tom", "Jones", "1"}) {
+		t.Fatal("Unexpected params: ", params)
+	}
+}
+
+func TestBuilderDeleteWithParams(t *testing.T) {
+	sql, params, err := NewBuilder(DIALECT_MYSQL).
+		Table("users").
+		Where(Where{Column: "id", Operator: "==", Value: "1"}).
+		DeleteWithParams()
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	expected := "DELETE FROM `users` WHERE `id` = ?;"
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+
+	if !reflect.DeepEqual(params, []any{"1"}) {
+		t.Fatal("Unexpected params: ", params)
+	}
+}
+
+func TestBuilderWithParamsDoesNotLeak(t *testing.T) {
+	builder := NewBuilder(DIALECT_SQLITE).
+		Table("users").
+		Where(Where{Column: "id", Operator: "==", Value: "1"})
+
+	_, _, err := builder.SelectWithParams([]string{"id"})
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	sql := builder.Select([]string{"id"})
+
+	expected := `SELECT "id" FROM "users" WHERE "id" = '1';`
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+}
+
+func TestBuilderWithParamsSqliteExec(t *testing.T) {
+	db, err := initSqliteDB("test_builder_params.db")
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	defer db.Close()
+
+	_, err = db.Exec(NewBuilder(DIALECT_SQLITE).
+		Table("users").
+		Column(Column{Name: "id", Type: COLUMN_TYPE_STRING, PrimaryKey: true}).
+		Column(Column{Name: "name", Type: COLUMN_TYPE_STRING}).
+		Create())
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	sql, params, err := NewBuilder(DIALECT_SQLITE).
+		Table("users").
+		InsertWithParams(map[string]string{"id": "1", "name": "O'Brien"})
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	_, err = db.Exec(sql, params...)
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	sql, params, err = NewBuilder(DIALECT_SQLITE).
+		Table("users").
+		Where(Where{Column: "name", Operator: "=", Value: "O'Brien"}).
+		SelectWithParams([]string{"id"})
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	rows, err := db.SelectToMapString(sql, params...)
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	if len(rows) != 1 || rows[0]["id"] != "1" {
+		t.Fatal("Expected one row with id 1 but got: ", rows)
+	}
+}
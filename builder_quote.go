@@ -1,86 +1,167 @@
 package sb
 
-import "strings"
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
 
+	"github.com/samber/lo"
+)
+
+// quote quotes an identifier (or a value, with the "value" quote type).
+// The quote characters inside the identifier are doubled (the closing
+// bracket for MSSQL), so the identifier can not end early
 func (b *Builder) quote(s string, quoteType string) string {
-	var quoteChar string
-	switch b.Dialect {
-	case DIALECT_MYSQL:
-		quoteChar = "`"
-	case DIALECT_POSTGRES:
-		quoteChar = `"`
-	case DIALECT_SQLITE:
-		quoteChar = `"`
+	if quoteType == "value" {
+		return b.quoteString(s)
 	}
 
-	if quoteType == "value" {
-		if b.Dialect == DIALECT_MYSQL {
-			return `"` + b.escapeMysql(s) + `"`
-		} else if b.Dialect == DIALECT_POSTGRES {
-			return `"` + b.escapePostgres(s) + `"`
-		} else if b.Dialect == DIALECT_SQLITE {
-			return `'` + b.escapeSqlite(s) + `'`
+	b.validateIdentifier(s)
+
+	return b.dialectOrFail().QuoteIdentifier(s)
+}
+
+// validateIdentifier checks an identifier is not empty, has no NUL
+// characters, and is within the length limit of the dialect
+func (b *Builder) validateIdentifier(identifier string) {
+	if err := b.dialectOrFail().ValidateIdentifier(identifier); err != nil {
+		b.fail(err)
+	}
+}
+
+func (b *Builder) quoteValue(value string) string {
+	if b.sqlParamsEnabled {
+		return b.paramAdd(value)
+	}
+
+	return b.quoteString(value)
+}
+
+// quoteString converts a string to a string literal of the dialect.
+// The literals are single quoted, so they are never taken as identifiers
+// (as double quoted strings are in PostgreSQL, and in MySQL ANSI_QUOTES mode),
+// and every character the dialect treats specially inside them is escaped
+func (b *Builder) quoteString(value string) string {
+	quoted, err := b.dialectOrFail().QuoteString(value)
+	if err != nil {
+		b.fail(err)
+	}
+
+	return quoted
+}
+
+// quoteValueAny quotes a typed value, as expected by the dialect:
+//   - nil (and nil pointers) become NULL
+//   - numbers are kept as they are
+//   - booleans become TRUE / FALSE, or 1 / 0 for SQLite and MSSQL
+//   - times are formatted as the dialect parses them
+//   - byte slices become hex (or bytea) literals
+//   - driver.Valuer values (i.e. sql.NullString) are quoted by their value
+//
+// Everything else is quoted as a string
+func (b *Builder) quoteValueAny(value any) string {
+	if valuer, ok := value.(driver.Valuer); ok {
+		if v := reflect.ValueOf(valuer); v.Kind() == reflect.Pointer && v.IsNil() {
+			return "NULL"
+		}
+
+		driverValue, err := valuer.Value()
+		if err != nil {
+			b.fail(fmt.Errorf("%w: failed to get the value of %s: %w", ErrInvalidQuery, reflect.TypeOf(value).String(), err))
+		}
+
+		return b.quoteValueAny(driverValue)
+	}
+
+	if v := reflect.ValueOf(value); v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return "NULL"
 		}
-		return s
+
+		return b.quoteValueAny(v.Elem().Interface())
+	}
+
+	if value == nil {
+		return "NULL"
 	}
 
-	if quoteChar != "" {
-		return quoteChar + s + quoteChar
+	if b.sqlParamsEnabled {
+		return b.paramAdd(value)
 	}
 
-	return s
+	switch v := value.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return toString(v)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return b.quoteBool(v)
+	case time.Time:
+		return b.quoteValue(b.formatTime(v))
+	case []byte:
+		return b.quoteBytes(v)
+	default:
+		return b.quoteValue(toString(v))
+	}
 }
 
-func (b *Builder) quoteValue(value string) string {
-	if b.Dialect == DIALECT_MYSQL {
-		value = `"` + b.escapeMysql(value) + `"`
+// isNullValue checks if a typed value is quoted as NULL
+func isNullValue(value any) bool {
+	if value == nil {
+		return true
 	}
 
-	if b.Dialect == DIALECT_POSTGRES {
-		value = `"` + b.escapePostgres(value) + `"`
+	if v := reflect.ValueOf(value); v.Kind() == reflect.Pointer && v.IsNil() {
+		return true
 	}
 
-	if b.Dialect == DIALECT_SQLITE {
-		value = `'` + b.escapeSqlite(value) + `'`
+	if valuer, ok := value.(driver.Valuer); ok {
+		driverValue, err := valuer.Value()
+		return err == nil && driverValue == nil
 	}
 
-	return value
+	return false
 }
 
-func (b *Builder) escapeMysql(value string) string {
-	// escapeRegexp       = regexp.MustCompile(`[\0\t\x1a\n\r\"\'\\]`)
-	// characterEscapeMap = map[string]string{
-	// 	"\\0":  `\\0`,  //ASCII NULL
-	// 	"\b":   `\\b`,  //backspace
-	// 	"\t":   `\\t`,  //tab
-	// 	"\x1a": `\\Z`,  //ASCII 26 (Control+Z);
-	// 	"\n":   `\\n`,  //newline character
-	// 	"\r":   `\\r`,  //return character
-	// 	"\"":   `\\"`,  //quote (")
-	// 	"'":    `\'`,   //quote (')
-	// 	"\\":   `\\\\`, //backslash (\)
-	// 	// "\\%":  `\\%`,  //% character
-	// 	// "\\_":  `\\_`,  //_ character
-	// }
-	// return escapeRegexp.ReplaceAllStringFunc(val, func(s string) string {
-
-	// 	mVal, ok := characterEscapeMap[s]
-	// 	if ok {
-	// 		return mVal
-	// 	}
-	// 	return s
-	// })
-
-	escapedStr := strings.ReplaceAll(value, `"`, `""`)
-	return escapedStr
+// quoteBool converts a boolean to SQL. SQLite (before 3.23)
+// and MSSQL have no TRUE and FALSE keywords
+func (b *Builder) quoteBool(value bool) string {
+	if b.syntax() == DIALECT_SQLITE || b.syntax() == DIALECT_MSSQL {
+		return lo.Ternary(value, "1", "0")
+	}
+
+	return lo.Ternary(value, "TRUE", "FALSE")
 }
 
-func (b *Builder) escapePostgres(value string) string {
-	escapedStr := strings.ReplaceAll(value, `"`, `""`)
-	return escapedStr
+// formatTime formats a time as the dialect parses it. MySQL and MSSQL
+// have no time zone in the DATETIME types, so the local time of the value
+// is used. SQLite uses the format of the Go drivers
+func (b *Builder) formatTime(value time.Time) string {
+	switch b.syntax() {
+	case DIALECT_MYSQL:
+		return value.Format("2006-01-02 15:04:05.999999")
+	case DIALECT_POSTGRES:
+		return value.Format("2006-01-02 15:04:05.999999-07:00")
+	case DIALECT_MSSQL:
+		return value.Format("2006-01-02T15:04:05.9999999")
+	default:
+		return value.Format("2006-01-02 15:04:05.999999999-07:00")
+	}
 }
 
-func (b *Builder) escapeSqlite(value string) string {
-	escapedStr := strings.ReplaceAll(value, "'", "''")
-	return escapedStr
+// quoteBytes converts a byte slice to a binary literal
+func (b *Builder) quoteBytes(value []byte) string {
+	switch b.syntax() {
+	case DIALECT_POSTGRES:
+		return `'\x` + hex.EncodeToString(value) + `'::bytea`
+	case DIALECT_MSSQL:
+		return "0x" + hex.EncodeToString(value)
+	default:
+		return "X'" + hex.EncodeToString(value) + "'"
+	}
 }
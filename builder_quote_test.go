@@ -0,0 +1,380 @@
+package sb
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestBuilderQuoteString(t *testing.T) {
+	tests := []struct {
+		dialect  string
+		value    string
+		expected string
+	}{
+		{DIALECT_MYSQL, `Tom`, `'Tom'`},
+		{DIALECT_MYSQL, `O'Brien`, `'O''Brien'`},
+		{DIALECT_MYSQL, `say "hi"`, `'say "hi"'`},
+		{DIALECT_MYSQL, `\' OR 1=1 --`, `'\\'' OR 1=1 --'`},
+		{DIALECT_MYSQL, "a\x00b\nc\rd\x1ae", `'a\0b\nc\rd\Ze'`},
+		{DIALECT_POSTGRES, `Tom`, `'Tom'`},
+		{DIALECT_POSTGRES, `O'Brien`, `'O''Brien'`},
+		{DIALECT_POSTGRES, `C:\temp\'`, `E'C:\\temp\\'''`},
+		{DIALECT_SQLITE, `O'Brien`, `'O''Brien'`},
+		{DIALECT_SQLITE, "a\x00b", `'a' || char(0) || 'b'`},
+		{DIALECT_MSSQL, `O'Brien`, `N'O''Brien'`},
+		{DIALECT_MSSQL, `Łódź`, `N'Łódź'`},
+	}
+
+	for _, test := range tests {
+		sql := NewBuilder(test.dialect).quoteString(test.value)
+
+		if sql != test.expected {
+			t.Fatal("Expected:\n", test.expected, "\nbut found:\n", sql)
+		}
+	}
+}
+
+func TestBuilderQuoteStringPostgresNul(t *testing.T) {
+	_, _, err := NewBuilder(DIALECT_POSTGRES).
+		Table("users").
+		UpdateWithParams(map[string]string{"name": "Tom"})
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	defer func() {
+		err, ok := recover().(error)
+
+		if !ok || !errors.Is(err, ErrInvalidQuery) {
+			t.Fatal("NUL characters must fail with ErrInvalidQuery for PostgreSQL but got: ", err)
+		}
+	}()
+
+	NewBuilder(DIALECT_POSTGRES).Table("users").Insert(map[string]string{"name": "a\x00b"})
+}
+
+// FuzzQuoteString checks that no string escapes its literal: the literal
+// generated for each dialect is parsed back as the dialect parses it, and
+// must be exactly one literal (or NUL concatenation for SQLite), holding
+// the original string. The SQLite literals are checked against SQLite too
+func FuzzQuoteString(f *testing.F) {
+	seeds := []string{
+		"",
+		"Tom",
+		"O'Brien",
+		`"; DROP TABLE users; --`,
+		`' OR '1'='1`,
+		`\' OR 1=1 --`,
+		`\\'`,
+		`\`,
+		`'`,
+		`''`,
+		"\x00",
+		"a\x00'\x00b",
+		"\x1a\n\r\t\b",
+		`]; DROP TABLE [users]; --`,
+		"N'x'",
+		"E'\\x'",
+		"Łódź ✓",
+		"\xff\xfe",
+	}
+
+	for _, seed := range seeds {
+		f.Add(seed)
+	}
+
+	db, err := sql.Open("sqlite3", ":memory:")
+
+	if err != nil {
+		f.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	defer db.Close()
+
+	f.Fuzz(func(t *testing.T, value string) {
+		for _, dialect := range []string{DIALECT_MYSQL, DIALECT_POSTGRES, DIALECT_SQLITE, DIALECT_MSSQL} {
+			if dialect == DIALECT_POSTGRES && strings.ContainsRune(value, 0) {
+				continue // Rejected, see TestBuilderQuoteStringPostgresNul
+			}
+
+			literal := NewBuilder(dialect).quoteString(value)
+
+			decoded, ok := parseStringLiteral(dialect, literal)
+
+			if !ok {
+				t.Fatalf("%s literal %q of %q is not a single literal", dialect, literal, value)
+			}
+
+			if decoded != value {
+				t.Fatalf("%s literal %q decodes to %q instead of %q", dialect, literal, decoded, value)
+			}
+		}
+
+		if !utf8.ValidString(value) {
+			return
+		}
+
+		var selected string
+		literal := NewBuilder(DIALECT_SQLITE).quoteString(value)
+
+		if err := db.QueryRow("SELECT " + literal).Scan(&selected); err != nil {
+			t.Fatalf("sqlite literal %q of %q fails: %s", literal, value, err.Error())
+		}
+
+		if selected != value {
+			t.Fatalf("sqlite literal %q selects %q instead of %q", literal, selected, value)
+		}
+	})
+}
+
+// parseStringLiteral parses a string literal as the dialect does, and
+// returns its value, and whether the literal is the whole SQL
+func parseStringLiteral(dialect string, literal string) (string, bool) {
+	switch dialect {
+	case DIALECT_MYSQL:
+		// With NO_BACKSLASH_ESCAPES the literal must not end early either
+		if _, ok := parseQuoted(literal, false); !ok {
+			return "", false
+		}
+		return parseQuoted(literal, true)
+	case DIALECT_POSTGRES:
+		if strings.HasPrefix(literal, "E") {
+			return parseQuoted(literal[1:], true)
+		}
+		// Without the E prefix, the literal must be the same with
+		// standard_conforming_strings on and off
+		if strings.Contains(literal, `\`) {
+			return "", false
+		}
+		return parseQuoted(literal, false)
+	case DIALECT_SQLITE:
+		parts := strings.Split(literal, " || char(0) || ")
+		values := []string{}
+		for _, part := range parts {
+			value, ok := parseQuoted(part, false)
+			if !ok {
+				return "", false
+			}
+			values = append(values, value)
+		}
+		return strings.Join(values, "\x00"), true
+	case DIALECT_MSSQL:
+		if !strings.HasPrefix(literal, "N") {
+			return "", false
+		}
+		return parseQuoted(literal[1:], false)
+	}
+
+	return "", false
+}
+
+// parseQuoted parses a single quoted literal, where a quote is escaped
+// by doubling it, and with backslash escapes if enabled. It fails if
+// the literal ends before the end of the SQL
+func parseQuoted(literal string, backslashEscapes bool) (string, bool) {
+	if len(literal) < 2 || literal[0] != '\'' {
+		return "", false
+	}
+
+	escapes := map[byte]byte{'0': 0, 'n': '\n', 'r': '\r', 'Z': 0x1a, 't': '\t', 'b': '\b'}
+	value := []byte{}
+
+	for i := 1; i < len(literal); i++ {
+		c := literal[i]
+
+		if backslashEscapes && c == '\\' {
+			if i+1 >= len(literal) {
+				return "", false
+			}
+			i++
+			if escaped, ok := escapes[literal[i]]; ok {
+				value = append(value, escaped)
+			} else {
+				value = append(value, literal[i])
+			}
+			continue
+		}
+
+		if c == '\'' {
+			if i+1 < len(literal) && literal[i+1] == '\'' {
+				value = append(value, '\'')
+				i++
+				continue
+			}
+			// The closing quote must end the SQL
+			return string(value), i == len(literal)-1
+		}
+
+		value = append(value, c)
+	}
+
+	return "", false
+}
+
+func TestBuilderQuoteIdentifier(t *testing.T) {
+	tests := []struct {
+		dialect  string
+		column   string
+		expected string
+	}{
+		{DIALECT_MYSQL, "users.first_name", "`users`.`first_name`"},
+		{DIALECT_MYSQL, "a`b", "`a``b`"},
+		{DIALECT_POSTGRES, `a"b`, `"a""b"`},
+		{DIALECT_POSTGRES, `id"; DROP TABLE users; --`, `"id""; DROP TABLE users; --"`},
+		{DIALECT_SQLITE, `a"b`, `"a""b"`},
+		{DIALECT_MSSQL, `a]b`, `[a]]b]`},
+		{DIALECT_MSSQL, `users.*`, `[users].*`},
+		{DIALECT_MYSQL, "COUNT(users.id)", "`COUNT(users`.`id)`"},
+	}
+
+	for _, test := range tests {
+		sql := NewBuilder(test.dialect).quoteColumn(test.column)
+
+		if sql != test.expected {
+			t.Fatal("Expected:\n", test.expected, "\nbut found:\n", sql)
+		}
+	}
+}
+
+func TestBuilderQuoteIdentifierFunctionCall(t *testing.T) {
+	column := "(SELECT password FROM users)"
+
+	tests := map[string]BuilderInterface{
+		"order by": NewBuilder(DIALECT_POSTGRES).Table("users").OrderBy(column, ASC),
+		"group by": NewBuilder(DIALECT_POSTGRES).Table("users").GroupBy(GroupBy{Column: column}),
+		"select":   NewBuilder(DIALECT_POSTGRES).Table("users").SelectColumns(column),
+	}
+
+	for name, builder := range tests {
+		sql, _, err := builder.Build()
+
+		if err != nil {
+			t.Fatal("Error must be NIL for ", name, " but got: ", err.Error())
+		}
+
+		if !strings.Contains(sql, `"(SELECT password FROM users)"`) {
+			t.Fatal("Expected the ", name, " column to be quoted but found: ", sql)
+		}
+	}
+
+	sql := NewBuilder(DIALECT_MYSQL).Table("users").Insert(map[string]string{"NOW()": "1"})
+
+	expected := "INSERT INTO `users` (`NOW()`) VALUES ('1');"
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+}
+
+func TestBuilderQuoteIdentifierInvalid(t *testing.T) {
+	tests := []struct {
+		dialect string
+		table   string
+	}{
+		{DIALECT_MYSQL, strings.Repeat("a", 65)},
+		{DIALECT_POSTGRES, strings.Repeat("a", 64)},
+		{DIALECT_POSTGRES, strings.Repeat("ł", 32)},
+		{DIALECT_MSSQL, strings.Repeat("a", 129)},
+		{DIALECT_SQLITE, "users."},
+		{DIALECT_SQLITE, "us\x00ers"},
+	}
+
+	for _, test := range tests {
+		_, _, err := NewBuilder(test.dialect).Table(test.table).Build()
+
+		if !errors.Is(err, ErrInvalidQuery) {
+			t.Fatal("Error must be ErrInvalidQuery for ", test.dialect, " ", test.table, " but got: ", err)
+		}
+	}
+
+	for _, table := range []string{strings.Repeat("a", 64), strings.Repeat("ł", 64)} {
+		_, _, err := NewBuilder(DIALECT_MYSQL).Table(table).Build()
+
+		if err != nil {
+			t.Fatal("Error must be NIL but got: ", err.Error())
+		}
+	}
+
+	_, _, err := NewBuilder(DIALECT_SQLITE).Table(strings.Repeat("a", 1000)).Build()
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+}
+
+func TestBuilderQuoteIdentifierInvalidTableAlter(t *testing.T) {
+	column := Column{Name: "status", Type: COLUMN_TYPE_STRING}
+
+	for _, dialect := range []string{DIALECT_MSSQL, DIALECT_MYSQL, DIALECT_POSTGRES, DIALECT_SQLITE} {
+		tests := []struct {
+			method string
+			alter  func(b BuilderInterface) (string, error)
+		}{
+			{"TableRename", func(b BuilderInterface) (string, error) { return b.TableRename("users", "") }},
+			{"TableRename", func(b BuilderInterface) (string, error) { return b.TableRename("", "users") }},
+			{"TableColumnAdd", func(b BuilderInterface) (string, error) { return b.TableColumnAdd("", column) }},
+			{"TableColumnAdd", func(b BuilderInterface) (string, error) {
+				return b.TableColumnAdd("users", Column{Type: COLUMN_TYPE_STRING})
+			}},
+			{"TableColumnChange", func(b BuilderInterface) (string, error) { return b.TableColumnChange("", column) }},
+			{"TableColumnDrop", func(b BuilderInterface) (string, error) { return b.TableColumnDrop("users", "") }},
+			{"TableColumnRename", func(b BuilderInterface) (string, error) { return b.TableColumnRename("users", "", "name") }},
+			{"TableColumnRename", func(b BuilderInterface) (string, error) { return b.TableColumnRename("users", "name", "") }},
+		}
+
+		for _, test := range tests {
+			// SQLite can not alter a column at all
+			if dialect == DIALECT_SQLITE && test.method == "TableColumnChange" {
+				continue
+			}
+
+			_, err := test.alter(NewBuilder(dialect))
+
+			if !errors.Is(err, ErrInvalidQuery) {
+				t.Fatal("Error must be ErrInvalidQuery for ", dialect, " ", test.method, " but got: ", err)
+			}
+		}
+	}
+
+	_, err := NewBuilder(DIALECT_POSTGRES).TableColumnChange("users", Column{Name: "status", Type: COLUMN_TYPE_STRING, Default: "a\x00b"})
+
+	if !errors.Is(err, ErrInvalidQuery) {
+		t.Fatal("Error must be ErrInvalidQuery for a default with a NUL character but got: ", err)
+	}
+}
+
+func TestBuilderQuoteIdentifierSqliteExec(t *testing.T) {
+	db, err := initSqliteDB("test_builder_identifier.db")
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	defer db.Close()
+
+	table := `odd"table`
+	column := `odd"column`
+
+	statements := []string{
+		NewBuilder(DIALECT_SQLITE).Table(table).Column(Column{Name: column, Type: COLUMN_TYPE_STRING}).Create(),
+		NewBuilder(DIALECT_SQLITE).Table(table).Insert(map[string]string{column: "value"}),
+	}
+
+	for _, statement := range statements {
+		if _, err := db.Exec(statement); err != nil {
+			t.Fatal("Error must be NIL but got: ", err.Error(), " for ", statement)
+		}
+	}
+
+	rows, err := db.SelectToMapString(NewBuilder(DIALECT_SQLITE).Table(table).Select([]string{column}))
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	if len(rows) != 1 || rows[0][column] != "value" {
+		t.Fatal("Expected one row with the value but found: ", rows)
+	}
+}
@@ -0,0 +1,73 @@
+package sb
+
+import (
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// Returning sets the columns of the inserted, updated or deleted rows
+// to be returned by the Insert, InsertMany, Upsert, Update and Delete
+// statements, all the columns if none are given. It is rendered as
+// RETURNING for PostgreSQL and SQLite (3.35 or newer), and as OUTPUT
+// INSERTED (or DELETED) for MSSQL. MySQL does not support it.
+//
+// The returned rows can be read with Database.ExecToMapAny
+//
+//	Example:
+//	// INSERT INTO "users" ("name") VALUES ('Tom') RETURNING "id";
+//	NewBuilder(DIALECT_SQLITE).
+//		Table("users").
+//		Returning("id").
+//		Insert(map[string]string{"name": "Tom"})
+func (b *Builder) Returning(columns ...string) BuilderInterface {
+	if len(columns) == 0 {
+		columns = []string{"*"}
+	}
+
+	b = b.mutable()
+	b.sqlReturning = columns
+
+	return b
+}
+
+// returningToSql converts the returning columns to a RETURNING clause,
+// used by PostgreSQL and SQLite at the end of the statement
+func (b *Builder) returningToSql() string {
+	if len(b.sqlReturning) == 0 {
+		return ""
+	}
+
+	if !b.supports(FEATURE_RETURNING) {
+		b.fail(b.errUnsupportedFeature("returning"))
+	}
+
+	if b.syntax() != DIALECT_POSTGRES && b.syntax() != DIALECT_SQLITE {
+		return ""
+	}
+
+	columns := lo.Map(b.sqlReturning, func(column string, _ int) string {
+		return b.quoteColumn(column)
+	})
+
+	return " RETURNING " + strings.Join(columns, ", ")
+}
+
+// outputToSql converts the returning columns to an OUTPUT clause,
+// used by MSSQL. The source is the INSERTED or DELETED pseudo table
+func (b *Builder) outputToSql(source string) string {
+	if len(b.sqlReturning) == 0 || b.syntax() != DIALECT_MSSQL {
+		return ""
+	}
+
+	if !b.supports(FEATURE_RETURNING) {
+		b.fail(b.errUnsupportedFeature("returning"))
+	}
+
+	// The columns are qualified by the pseudo table, instead of the table
+	columns := lo.Map(b.sqlReturning, func(column string, _ int) string {
+		return source + "." + b.quoteColumn(column[strings.LastIndex(column, ".")+1:])
+	})
+
+	return " OUTPUT " + strings.Join(columns, ", ")
+}
@@ -0,0 +1,160 @@
+package sb
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBuilderReturningInsert(t *testing.T) {
+	tests := []struct {
+		dialect  string
+		expected string
+	}{
+		{DIALECT_POSTGRES, `INSERT INTO "users" ("name") VALUES ('Tom') RETURNING "id", "name";`},
+		{DIALECT_SQLITE, `INSERT INTO "users" ("name") VALUES ('Tom') RETURNING "id", "name";`},
+		{DIALECT_MSSQL, `INSERT INTO [users] ([name]) OUTPUT INSERTED.[id], INSERTED.[name] VALUES (N'Tom');`},
+	}
+
+	for _, test := range tests {
+		sql := NewBuilder(test.dialect).
+			Table("users").
+			Returning("id", "name").
+			Insert(map[string]string{"name": "Tom"})
+
+		if sql != test.expected {
+			t.Fatal("Expected:\n", test.expected, "\nbut found:\n", sql)
+		}
+	}
+}
+
+func TestBuilderReturningUpdate(t *testing.T) {
+	tests := []struct {
+		dialect  string
+		expected string
+	}{
+		{DIALECT_POSTGRES, `UPDATE "users" SET "name"='Tom' WHERE "id" = '1' RETURNING *;`},
+		{DIALECT_SQLITE, `UPDATE "users" SET "name"='Tom' WHERE "id" = '1' RETURNING *;`},
+		{DIALECT_MSSQL, `UPDATE [users] SET [name]=N'Tom' OUTPUT INSERTED.* WHERE [id] = N'1';`},
+	}
+
+	for _, test := range tests {
+		sql := NewBuilder(test.dialect).
+			Table("users").
+			Where(Where{Column: "id", Operator: "=", Value: "1"}).
+			Returning().
+			Update(map[string]string{"name": "Tom"})
+
+		if sql != test.expected {
+			t.Fatal("Expected:\n", test.expected, "\nbut found:\n", sql)
+		}
+	}
+}
+
+func TestBuilderReturningDelete(t *testing.T) {
+	tests := []struct {
+		dialect  string
+		expected string
+	}{
+		{DIALECT_POSTGRES, `DELETE FROM "users" USING "bans" WHERE "users"."id" = "bans"."user_id" RETURNING "users"."id";`},
+		{DIALECT_SQLITE, `DELETE FROM "users" WHERE rowid IN (SELECT "users".rowid FROM "users" INNER JOIN "bans" ON "users"."id" = "bans"."user_id") RETURNING "users"."id";`},
+		{DIALECT_MSSQL, `DELETE [users] OUTPUT DELETED.[id] FROM [users] INNER JOIN [bans] ON [users].[id] = [bans].[user_id];`},
+	}
+
+	for _, test := range tests {
+		sql := NewBuilder(test.dialect).
+			Table("users").
+			Join(Join{Table: "bans", On: []JoinOn{{Column: "users.id", OtherColumn: "bans.user_id"}}}).
+			Returning("users.id").
+			Delete()
+
+		if sql != test.expected {
+			t.Fatal("Expected:\n", test.expected, "\nbut found:\n", sql)
+		}
+	}
+
+	sql := NewBuilder(DIALECT_SQLITE).
+		Table("users").
+		Where(Where{Column: "id", Operator: "=", Value: "1"}).
+		Returning("id").
+		Delete()
+
+	expected := `DELETE FROM "users" WHERE "id" = '1' RETURNING "id";`
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+}
+
+func TestBuilderReturningUpsert(t *testing.T) {
+	sql := NewBuilder(DIALECT_POSTGRES).
+		Table("users").
+		Returning("id").
+		Upsert(map[string]string{"email": "tom@test.com", "name": "Tom"}, Upsert{ConflictColumns: []string{"email"}})
+
+	expected := `INSERT INTO "users" ("email", "name") VALUES ('tom@test.com', 'Tom') ON CONFLICT ("email") DO UPDATE SET "name" = EXCLUDED."name" RETURNING "id";`
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+}
+
+func TestBuilderReturningMysqlUnsupported(t *testing.T) {
+	_, _, err := NewBuilder(DIALECT_MYSQL).
+		Table("users").
+		Returning("id").
+		InsertWithParams(map[string]string{"name": "Tom"})
+
+	if !errors.Is(err, ErrUnsupportedFeature) {
+		t.Fatal("Error must be ErrUnsupportedFeature but got: ", err)
+	}
+}
+
+func TestBuilderReturningSqliteExec(t *testing.T) {
+	db, err := initSqliteDB("test_builder_returning.db")
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	defer db.Close()
+
+	_, err = db.Exec(NewBuilder(DIALECT_SQLITE).Table("users").
+		Column(Column{Name: "id", Type: COLUMN_TYPE_INTEGER, PrimaryKey: true, AutoIncrement: true}).
+		Column(Column{Name: "name", Type: COLUMN_TYPE_STRING}).
+		Create())
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	sqls, params, err := NewBuilder(DIALECT_SQLITE).
+		Table("users").
+		Returning("id", "name").
+		InsertManyWithParams([]map[string]string{{"name": "Tom"}, {"name": "Ann"}})
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	rows, err := db.ExecToMapString(sqls[0], params[0]...)
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	if len(rows) != 2 || rows[0]["id"] != "1" || rows[1]["name"] != "Ann" {
+		t.Fatal("Unexpected inserted rows: ", rows)
+	}
+
+	rows, err = db.ExecToMapString(NewBuilder(DIALECT_SQLITE).
+		Table("users").
+		Where(Where{Column: "name", Operator: "=", Value: "Tom"}).
+		Returning("id").
+		Delete())
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	if len(rows) != 1 || rows[0]["id"] != "1" {
+		t.Fatal("Unexpected deleted rows: ", rows)
+	}
+}
@@ -0,0 +1,98 @@
+package sb
+
+import (
+	"strings"
+)
+
+// Subquery is a select query used inside another query, as a where
+// value, an EXISTS condition, a FROM source, or a select list column
+//
+//	Example:
+//	// SELECT * FROM "users" WHERE "id" IN (SELECT "user_id" FROM "orders");
+//	NewBuilder(DIALECT_SQLITE).
+//		Table("users").
+//		Where(Where{Column: "id", Operator: OPERATOR_IN, Subquery: &Subquery{
+//			Query:   NewBuilder(DIALECT_SQLITE).Table("orders"),
+//			Columns: []string{"user_id"},
+//		}}).
+//		Select([]string{})
+type Subquery struct {
+	// Query is the builder of the subquery, must have the same dialect
+	Query BuilderInterface
+
+	// Columns are the columns to select, all if empty
+	Columns []string
+
+	// Alias is the name of the subquery, required in FROM and select list
+	Alias string
+}
+
+// FromSubquery selects from a subquery instead of a table
+func (b *Builder) FromSubquery(subquery Subquery) BuilderInterface {
+	if subquery.Alias == "" {
+		return b.errorAdd(errInvalidQuery("subquery alias is required"))
+	}
+
+	b = b.mutable()
+	b.sqlFromSubquery = &subquery
+	return b
+}
+
+// SelectSubquery adds a scalar subquery to the select list,
+// after the columns passed to Select
+func (b *Builder) SelectSubquery(subquery Subquery) BuilderInterface {
+	if subquery.Alias == "" {
+		return b.errorAdd(errInvalidQuery("subquery alias is required"))
+	}
+
+	b = b.mutable()
+	b.sqlSelectSubqueries = append(b.sqlSelectSubqueries, subquery)
+	return b
+}
+
+// selectSubqueriesToSql converts the select list subqueries to SQL
+func (b *Builder) selectSubqueriesToSql(subqueries []Subquery) string {
+	sql := []string{}
+
+	for _, subquery := range subqueries {
+		sql = append(sql, b.subqueryToSql(subquery)+" AS "+b.quoteColumn(subquery.Alias))
+	}
+
+	return strings.Join(sql, ", ")
+}
+
+// subqueryToSql converts the subquery to parenthesised SQL
+func (b *Builder) subqueryToSql(subquery Subquery) string {
+	return "(" + b.subquerySelectToSql(subquery) + ")"
+}
+
+// subquerySelectToSql converts the subquery to SQL. When the
+// parameters are collected, the subquery adds its parameters to the
+// ones of this builder, so the placeholders are numbered correctly
+func (b *Builder) subquerySelectToSql(subquery Subquery) string {
+	query, ok := subquery.Query.(*Builder)
+
+	if !ok || query == nil {
+		b.fail(errInvalidQuery("subquery must be created with NewBuilder"))
+	}
+
+	if query.Dialect != b.Dialect {
+		b.fail(errInvalidQuery("subquery dialect " + query.Dialect + " does not match dialect " + b.Dialect))
+	}
+
+	query.failOnError()
+
+	// A copy of the subquery builder collects the parameters,
+	// so the subquery builder itself is not changed
+	collector := *query
+	collector.sqlParamsEnabled = b.sqlParamsEnabled
+	collector.sqlParams = b.sqlParams
+
+	sql := collector.selectToSql(subquery.Columns)
+
+	if b.sqlParamsEnabled {
+		b.sqlParams = collector.sqlParams
+	}
+
+	return sql
+}
@@ -0,0 +1,184 @@
+package sb
+
+import (
+	"testing"
+)
+
+func TestBuilderSubqueryWhereIn(t *testing.T) {
+	tests := []struct {
+		dialect  string
+		expected string
+	}{
+		{DIALECT_MYSQL, "SELECT * FROM `users` WHERE `id` IN (SELECT `user_id` FROM `orders` WHERE `total` > 100);"},
+		{DIALECT_POSTGRES, `SELECT * FROM "users" WHERE "id" IN (SELECT "user_id" FROM "orders" WHERE "total" > 100);`},
+		{DIALECT_SQLITE, `SELECT * FROM "users" WHERE "id" IN (SELECT "user_id" FROM "orders" WHERE "total" > 100);`},
+	}
+
+	for _, test := range tests {
+		orders := NewBuilder(test.dialect).
+			Table("orders").
+			Where(Where{Column: "total", Operator: ">", Values: []any{100}})
+
+		sql := NewBuilder(test.dialect).
+			Table("users").
+			Where(Where{Column: "id", Operator: OPERATOR_IN, Subquery: &Subquery{Query: orders, Columns: []string{"user_id"}}}).
+			Select([]string{})
+
+		if sql != test.expected {
+			t.Fatal("Expected:\n", test.expected, "\nbut found:\n", sql)
+		}
+	}
+}
+
+func TestBuilderSubqueryWhereExists(t *testing.T) {
+	bans := NewBuilder(DIALECT_SQLITE).
+		Table("bans").
+		Where(Where{Column: "active", Operator: "=", Value: "yes"})
+
+	sql := NewBuilder(DIALECT_SQLITE).
+		Table("users").
+		Where(Where{Column: "status", Operator: "=", Value: "active"}).
+		Where(Where{Operator: OPERATOR_NOT_EXISTS, Subquery: &Subquery{Query: bans}}).
+		OrWhere(Where{Operator: OPERATOR_EXISTS, Subquery: &Subquery{Query: bans, Columns: []string{"id"}}}).
+		Select([]string{})
+
+	expected := `SELECT * FROM "users" WHERE "status" = 'active' AND NOT EXISTS (SELECT * FROM "bans" WHERE "active" = 'yes') OR EXISTS (SELECT "id" FROM "bans" WHERE "active" = 'yes');`
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+}
+
+func TestBuilderSubqueryFrom(t *testing.T) {
+	totals := NewBuilder(DIALECT_POSTGRES).
+		Table("orders").
+		GroupBy(GroupBy{Column: "user_id"}).
+		SelectExpression(Expression{Function: FUNCTION_SUM, Columns: []string{"total"}, Alias: "total"})
+
+	sql := NewBuilder(DIALECT_POSTGRES).
+		FromSubquery(Subquery{Query: totals, Columns: []string{"user_id"}, Alias: "t"}).
+		Where(Where{Column: "t.total", Operator: ">", Values: []any{100}}).
+		Select([]string{"t.user_id"})
+
+	expected := `SELECT "t"."user_id" FROM (SELECT "user_id", SUM("total") AS "total" FROM "orders" GROUP BY "user_id") AS "t" WHERE "t"."total" > 100;`
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+}
+
+func TestBuilderSubquerySelectList(t *testing.T) {
+	count := NewBuilder(DIALECT_MYSQL).Table("orders").SelectExpression(Expression{Function: FUNCTION_COUNT})
+
+	sql := NewBuilder(DIALECT_MYSQL).
+		Table("users").
+		SelectSubquery(Subquery{Query: count, Alias: "orders_count"}).
+		Select([]string{"id"})
+
+	expected := "SELECT `id`, (SELECT COUNT(*) FROM `orders`) AS `orders_count` FROM `users`;"
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+}
+
+func TestBuilderSubqueryWithParams(t *testing.T) {
+	orders := NewBuilder(DIALECT_POSTGRES).
+		Table("orders").
+		Where(Where{Column: "status", Operator: "=", Value: "paid"})
+
+	count := NewBuilder(DIALECT_POSTGRES).
+		Table("logins").
+		Where(Where{Column: "success", Operator: "=", Value: "yes"}).
+		SelectExpression(Expression{Function: FUNCTION_COUNT})
+
+	sql, params, err := NewBuilder(DIALECT_POSTGRES).
+		SelectSubquery(Subquery{Query: count, Alias: "logins"}).
+		FromSubquery(Subquery{Query: NewBuilder(DIALECT_POSTGRES).Table("users").Where(Where{Column: "role", Operator: "=", Value: "admin"}), Alias: "u"}).
+		Where(Where{Column: "u.id", Operator: OPERATOR_IN, Subquery: &Subquery{Query: orders, Columns: []string{"user_id"}}}).
+		Where(Where{Column: "u.status", Operator: "=", Value: "active"}).
+		SelectWithParams([]string{"u.id"})
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	expected := `SELECT "u"."id", (SELECT COUNT(*) FROM "logins" WHERE "success" = $1) AS "logins" FROM (SELECT * FROM "users" WHERE "role" = $2) AS "u" WHERE "u"."id" IN (SELECT "user_id" FROM "orders" WHERE "status" = $3) AND "u"."status" = $4;`
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+
+	if len(params) != 4 || params[0] != "yes" || params[1] != "admin" || params[2] != "paid" || params[3] != "active" {
+		t.Fatal("Unexpected params: ", params)
+	}
+
+	// The subqueries must not keep the parameter mode
+	sql = orders.Select([]string{"user_id"})
+
+	expected = `SELECT "user_id" FROM "orders" WHERE "status" = 'paid';`
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+}
+
+func TestBuilderSubqueryDialectMismatchPanics(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("Subquery with different dialect must panic")
+		}
+	}()
+
+	NewBuilder(DIALECT_MYSQL).
+		Table("users").
+		Where(Where{Column: "id", Operator: OPERATOR_IN, Subquery: &Subquery{Query: NewBuilder(DIALECT_SQLITE).Table("orders")}}).
+		Select([]string{})
+}
+
+func TestBuilderSubquerySqliteExec(t *testing.T) {
+	db, err := initSqliteDB("test_builder_subquery.db")
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	defer db.Close()
+
+	statements := []string{
+		NewBuilder(DIALECT_SQLITE).Table("users").
+			Column(Column{Name: "id", Type: COLUMN_TYPE_STRING, PrimaryKey: true}).
+			Create(),
+		NewBuilder(DIALECT_SQLITE).Table("orders").
+			Column(Column{Name: "user_id", Type: COLUMN_TYPE_STRING}).
+			Column(Column{Name: "total", Type: COLUMN_TYPE_INTEGER}).
+			Create(),
+		`INSERT INTO "users" ("id") VALUES ('a'), ('b'), ('c')`,
+		`INSERT INTO "orders" ("user_id", "total") VALUES ('a', 10), ('a', 20), ('b', 5)`,
+	}
+
+	for _, statement := range statements {
+		_, err = db.Exec(statement)
+		if err != nil {
+			t.Fatal("Error must be NIL but got: ", err.Error(), " for ", statement)
+		}
+	}
+
+	orders := NewBuilder(DIALECT_SQLITE).
+		Table("orders").
+		Where(Where{Column: "total", Operator: ">=", Values: []any{10}})
+
+	sql, params, err := NewBuilder(DIALECT_SQLITE).
+		Table("users").
+		Where(Where{Column: "id", Operator: OPERATOR_IN, Subquery: &Subquery{Query: orders, Columns: []string{"user_id"}}}).
+		SelectWithParams([]string{"id"})
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	rows, err := db.SelectToMapString(sql, params...)
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	if len(rows) != 1 || rows[0]["id"] != "a" {
+		t.Fatal("Expected only user a but got: ", rows)
+	}
+}
@@ -0,0 +1,163 @@
+package sb
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// Upsert describes what to do when an inserted row conflicts
+// with an existing one
+//
+//	Example:
+//	// INSERT INTO "users" ("email", "name") VALUES ('tom@test.com', 'Tom')
+//	// ON CONFLICT ("email") DO UPDATE SET "name" = EXCLUDED."name";
+//	NewBuilder(DIALECT_SQLITE).
+//		Table("users").
+//		Upsert(map[string]string{"email": "tom@test.com", "name": "Tom"}, Upsert{
+//			ConflictColumns: []string{"email"},
+//			UpdateColumns:   []string{"name"},
+//		})
+type Upsert struct {
+	// ConflictColumns are the columns of the unique key, or the primary key,
+	// the conflict is detected on. MySQL ignores them, as it detects
+	// the conflicts on any unique key
+	ConflictColumns []string
+
+	// UpdateColumns are the columns updated with the inserted values
+	// on conflict. When empty, all the inserted columns except
+	// the conflict columns are updated
+	UpdateColumns []string
+
+	// DoNothing keeps the existing row on conflict
+	DoNothing bool
+}
+
+// Upsert inserts a row into the table, or on conflict updates
+// the existing row (or keeps it, if DoNothing is set), in one statement:
+//   - INSERT ... ON CONFLICT for PostgreSQL and SQLite
+//   - INSERT ... ON DUPLICATE KEY UPDATE, or INSERT IGNORE, for MySQL
+//   - MERGE for MSSQL
+func (b *Builder) Upsert(columnValuesMap map[string]string, upsert Upsert) string {
+	b.failOnError()
+
+	if b.sqlTableName == "" {
+		b.fail(errNoTable("Upsert"))
+	}
+
+	if !b.supports(FEATURE_UPSERT) {
+		b.fail(b.errUnsupportedFeature("upsert"))
+	}
+
+	if len(columnValuesMap) == 0 {
+		b.fail(errInvalidQuery("in method Upsert() no columns specified to insert"))
+	}
+
+	if len(upsert.ConflictColumns) == 0 && b.syntax() != DIALECT_MYSQL {
+		b.fail(errInvalidQuery("in method Upsert() no conflict columns specified for dialect " + b.Dialect))
+	}
+
+	columns := lo.Keys(columnValuesMap)
+	sort.Strings(columns)
+
+	updateColumns := upsert.UpdateColumns
+	if len(updateColumns) == 0 {
+		updateColumns = lo.Without(columns, upsert.ConflictColumns...)
+	}
+
+	// Nothing left to update, the existing row is kept
+	doNothing := upsert.DoNothing || len(updateColumns) == 0
+
+	columnNames := lo.Map(columns, func(column string, _ int) string {
+		return b.quoteColumn(column)
+	})
+
+	columnValues := lo.Map(columns, func(column string, _ int) string {
+		return b.quoteValue(columnValuesMap[column])
+	})
+
+	table := b.quoteTable(b.sqlTableName)
+	values := "(" + strings.Join(columnValues, ", ") + ")"
+
+	switch b.syntax() {
+	case DIALECT_MYSQL:
+		if doNothing {
+			return "INSERT IGNORE INTO " + table + " (" + strings.Join(columnNames, ", ") + ") VALUES " + values + b.returningToSql() + ";"
+		}
+
+		set := lo.Map(updateColumns, func(column string, _ int) string {
+			return b.quoteColumn(column) + " = VALUES(" + b.quoteColumn(column) + ")"
+		})
+
+		return "INSERT INTO " + table + " (" + strings.Join(columnNames, ", ") + ") VALUES " + values + " ON DUPLICATE KEY UPDATE " + strings.Join(set, ", ") + b.returningToSql() + ";"
+	case DIALECT_POSTGRES, DIALECT_SQLITE:
+		conflict := lo.Map(upsert.ConflictColumns, func(column string, _ int) string {
+			return b.quoteColumn(column)
+		})
+
+		sql := "INSERT INTO " + table + " (" + strings.Join(columnNames, ", ") + ") VALUES " + values + " ON CONFLICT (" + strings.Join(conflict, ", ") + ")"
+
+		if doNothing {
+			return sql + " DO NOTHING" + b.returningToSql() + ";"
+		}
+
+		set := lo.Map(updateColumns, func(column string, _ int) string {
+			return b.quoteColumn(column) + " = EXCLUDED." + b.quoteColumn(column)
+		})
+
+		return sql + " DO UPDATE SET " + strings.Join(set, ", ") + b.returningToSql() + ";"
+	case DIALECT_MSSQL:
+		return b.upsertMergeToSql(columns, values, upsert.ConflictColumns, updateColumns, doNothing)
+	}
+
+	b.fail(errUnsupportedDialect(b.Dialect))
+	return ""
+}
+
+// UpsertWithParams works like Upsert, but returns the values as bound parameters
+func (b *Builder) UpsertWithParams(columnValuesMap map[string]string, upsert Upsert) (sql string, params []any, err error) {
+	defer recoverError(&err)
+
+	if b.sqlTableName == "" {
+		return "", nil, errNoTable("UpsertWithParams")
+	}
+
+	sql, params = b.withParams(func(b *Builder) string {
+		return b.Upsert(columnValuesMap, upsert)
+	})
+
+	return sql, params, nil
+}
+
+// upsertMergeToSql converts an upsert to a MSSQL MERGE statement. The HOLDLOCK
+// hint keeps concurrent merges of the same row from both inserting it
+func (b *Builder) upsertMergeToSql(columns []string, values string, conflictColumns []string, updateColumns []string, doNothing bool) string {
+	table := b.quoteTable(b.sqlTableName)
+
+	columnNames := lo.Map(columns, func(column string, _ int) string {
+		return b.quoteColumn(column)
+	})
+
+	on := lo.Map(conflictColumns, func(column string, _ int) string {
+		return "target." + b.quoteColumn(column) + " = source." + b.quoteColumn(column)
+	})
+
+	sourceValues := lo.Map(columns, func(column string, _ int) string {
+		return "source." + b.quoteColumn(column)
+	})
+
+	sql := "MERGE INTO " + table + " WITH (HOLDLOCK) AS target" +
+		" USING (VALUES " + values + ") AS source (" + strings.Join(columnNames, ", ") + ")" +
+		" ON " + strings.Join(on, " AND ")
+
+	if !doNothing {
+		set := lo.Map(updateColumns, func(column string, _ int) string {
+			return "target." + b.quoteColumn(column) + " = source." + b.quoteColumn(column)
+		})
+
+		sql += " WHEN MATCHED THEN UPDATE SET " + strings.Join(set, ", ")
+	}
+
+	return sql + " WHEN NOT MATCHED THEN INSERT (" + strings.Join(columnNames, ", ") + ") VALUES (" + strings.Join(sourceValues, ", ") + ")" + b.outputToSql("INSERTED") + ";"
+}
@@ -0,0 +1,139 @@
+package sb
+
+import (
+	"testing"
+)
+
+func TestBuilderUpsert(t *testing.T) {
+	tests := []struct {
+		dialect  string
+		expected string
+	}{
+		{DIALECT_MYSQL, "INSERT INTO `users` (`email`, `name`, `status`) VALUES ('tom@test.com', 'Tom', 'active') ON DUPLICATE KEY UPDATE `name` = VALUES(`name`), `status` = VALUES(`status`);"},
+		{DIALECT_POSTGRES, `INSERT INTO "users" ("email", "name", "status") VALUES ('tom@test.com', 'Tom', 'active') ON CONFLICT ("email") DO UPDATE SET "name" = EXCLUDED."name", "status" = EXCLUDED."status";`},
+		{DIALECT_SQLITE, `INSERT INTO "users" ("email", "name", "status") VALUES ('tom@test.com', 'Tom', 'active') ON CONFLICT ("email") DO UPDATE SET "name" = EXCLUDED."name", "status" = EXCLUDED."status";`},
+		{DIALECT_MSSQL, `MERGE INTO [users] WITH (HOLDLOCK) AS target USING (VALUES (N'tom@test.com', N'Tom', N'active')) AS source ([email], [name], [status]) ON target.[email] = source.[email] WHEN MATCHED THEN UPDATE SET target.[name] = source.[name], target.[status] = source.[status] WHEN NOT MATCHED THEN INSERT ([email], [name], [status]) VALUES (source.[email], source.[name], source.[status]);`},
+	}
+
+	for _, test := range tests {
+		sql := NewBuilder(test.dialect).
+			Table("users").
+			Upsert(map[string]string{"email": "tom@test.com", "name": "Tom", "status": "active"}, Upsert{
+				ConflictColumns: []string{"email"},
+			})
+
+		if sql != test.expected {
+			t.Fatal("Expected:\n", test.expected, "\nbut found:\n", sql)
+		}
+	}
+}
+
+func TestBuilderUpsertDoNothing(t *testing.T) {
+	tests := []struct {
+		dialect  string
+		expected string
+	}{
+		{DIALECT_MYSQL, "INSERT IGNORE INTO `users` (`email`, `name`) VALUES ('tom@test.com', 'Tom');"},
+		{DIALECT_POSTGRES, `INSERT INTO "users" ("email", "name") VALUES ('tom@test.com', 'Tom') ON CONFLICT ("email") DO NOTHING;`},
+		{DIALECT_SQLITE, `INSERT INTO "users" ("email", "name") VALUES ('tom@test.com', 'Tom') ON CONFLICT ("email") DO NOTHING;`},
+		{DIALECT_MSSQL, `MERGE INTO [users] WITH (HOLDLOCK) AS target USING (VALUES (N'tom@test.com', N'Tom')) AS source ([email], [name]) ON target.[email] = source.[email] WHEN NOT MATCHED THEN INSERT ([email], [name]) VALUES (source.[email], source.[name]);`},
+	}
+
+	for _, test := range tests {
+		sql := NewBuilder(test.dialect).
+			Table("users").
+			Upsert(map[string]string{"email": "tom@test.com", "name": "Tom"}, Upsert{
+				ConflictColumns: []string{"email"},
+				DoNothing:       true,
+			})
+
+		if sql != test.expected {
+			t.Fatal("Expected:\n", test.expected, "\nbut found:\n", sql)
+		}
+	}
+}
+
+func TestBuilderUpsertUpdateColumns(t *testing.T) {
+	sql := NewBuilder(DIALECT_POSTGRES).
+		Table("users").
+		Upsert(map[string]string{"email": "tom@test.com", "name": "Tom", "created_at": "2020-01-01"}, Upsert{
+			ConflictColumns: []string{"email"},
+			UpdateColumns:   []string{"name"},
+		})
+
+	expected := `INSERT INTO "users" ("created_at", "email", "name") VALUES ('2020-01-01', 'tom@test.com', 'Tom') ON CONFLICT ("email") DO UPDATE SET "name" = EXCLUDED."name";`
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+}
+
+func TestBuilderUpsertNoConflictColumnsPanics(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("Upsert without conflict columns must panic for SQLite")
+		}
+	}()
+
+	NewBuilder(DIALECT_SQLITE).
+		Table("users").
+		Upsert(map[string]string{"email": "tom@test.com"}, Upsert{})
+}
+
+func TestBuilderUpsertWithParams(t *testing.T) {
+	sql, params, err := NewBuilder(DIALECT_MSSQL).
+		Table("users").
+		UpsertWithParams(map[string]string{"email": "tom@test.com", "name": "Tom"}, Upsert{
+			ConflictColumns: []string{"email"},
+		})
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	expected := `MERGE INTO [users] WITH (HOLDLOCK) AS target USING (VALUES (@p1, @p2)) AS source ([email], [name]) ON target.[email] = source.[email] WHEN MATCHED THEN UPDATE SET target.[name] = source.[name] WHEN NOT MATCHED THEN INSERT ([email], [name]) VALUES (source.[email], source.[name]);`
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+
+	if len(params) != 2 || params[0] != "tom@test.com" || params[1] != "Tom" {
+		t.Fatal("Unexpected params: ", params)
+	}
+}
+
+func TestBuilderUpsertSqliteExec(t *testing.T) {
+	db, err := initSqliteDB("test_builder_upsert.db")
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	defer db.Close()
+
+	statements := []string{
+		NewBuilder(DIALECT_SQLITE).Table("users").
+			Column(Column{Name: "email", Type: COLUMN_TYPE_STRING, PrimaryKey: true}).
+			Column(Column{Name: "name", Type: COLUMN_TYPE_STRING}).
+			Create(),
+		NewBuilder(DIALECT_SQLITE).Table("users").Upsert(map[string]string{"email": "tom@test.com", "name": "Tom"}, Upsert{ConflictColumns: []string{"email"}}),
+		NewBuilder(DIALECT_SQLITE).Table("users").Upsert(map[string]string{"email": "tom@test.com", "name": "Thomas"}, Upsert{ConflictColumns: []string{"email"}}),
+		NewBuilder(DIALECT_SQLITE).Table("users").Upsert(map[string]string{"email": "tom@test.com", "name": "Tommy"}, Upsert{ConflictColumns: []string{"email"}, DoNothing: true}),
+		NewBuilder(DIALECT_SQLITE).Table("users").Upsert(map[string]string{"email": "ann@test.com", "name": "Ann"}, Upsert{ConflictColumns: []string{"email"}, DoNothing: true}),
+	}
+
+	for _, statement := range statements {
+		_, err = db.Exec(statement)
+		if err != nil {
+			t.Fatal("Error must be NIL but got: ", err.Error(), " for ", statement)
+		}
+	}
+
+	rows, err := db.SelectToMapString(NewBuilder(DIALECT_SQLITE).Table("users").OrderBy("email", ASC).Select([]string{"email", "name"}))
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	if len(rows) != 2 || rows[0]["name"] != "Ann" || rows[1]["name"] != "Thomas" {
+		t.Fatal("Unexpected rows: ", rows)
+	}
+}
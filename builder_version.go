@@ -0,0 +1,22 @@
+package sb
+
+// Version sets the version of the database server, i.e. "3.31.1" for
+// SQLite or "5.7.44" for MySQL, so the features not available in that
+// version are reported as unsupported. Without a version, the latest
+// version of the dialect is assumed
+//
+//	Example:
+//	b := NewBuilder(DIALECT_SQLITE).Version("3.31.1")
+//	b.Supports(FEATURE_RETURNING) // false, RETURNING requires SQLite 3.35
+func (b *Builder) Version(version string) BuilderInterface {
+	b = b.mutable()
+	b.version = version
+
+	return b
+}
+
+// Supports checks if the dialect of the builder supports
+// a feature (one of the FEATURE_* constants) in the version
+func (b *Builder) Supports(feature Feature) bool {
+	return b.supports(feature)
+}
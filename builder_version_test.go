@@ -0,0 +1,150 @@
+package sb
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBuilderSupports(t *testing.T) {
+	tests := []struct {
+		dialect  string
+		version  string
+		feature  Feature
+		expected bool
+	}{
+		{DIALECT_SQLITE, "", FEATURE_RETURNING, true},
+		{DIALECT_SQLITE, "3.34.1", FEATURE_RETURNING, false},
+		{DIALECT_SQLITE, "3.35.0", FEATURE_RETURNING, true},
+		{DIALECT_SQLITE, "3.31.1", FEATURE_DROP_COLUMN, false},
+		{DIALECT_SQLITE, "3.45.1", FEATURE_DROP_COLUMN, true},
+		{DIALECT_SQLITE, "3.24.0", FEATURE_UPSERT, true},
+		{DIALECT_SQLITE, "3.22.0", FEATURE_UPSERT, false},
+		{DIALECT_SQLITE, "3.45.1", FEATURE_DISTINCT_ON, false},
+		{DIALECT_MYSQL, "5.7.44-log", FEATURE_CTE, false},
+		{DIALECT_MYSQL, "8.0.36-0ubuntu0.22.04.1", FEATURE_CTE, true},
+		{DIALECT_MYSQL, "5.7.44", FEATURE_WINDOW_FUNCTIONS, false},
+		{DIALECT_MYSQL, "8.0.36", FEATURE_RETURNING, false},
+		{DIALECT_MYSQL, "5.7.44", FEATURE_UPSERT, true},
+		{DIALECT_MYSQL, "5.7.44", FEATURE_SKIP_LOCKED, false},
+		{DIALECT_MYSQL, "8.0.36", FEATURE_FOR_SHARE, true},
+		{DIALECT_POSTGRES, "9.4.26", FEATURE_UPSERT, false},
+		{DIALECT_POSTGRES, "16.2 (Debian 16.2-1.pgdg120+2)", FEATURE_UPSERT, true},
+		{DIALECT_POSTGRES, "16.2", FEATURE_DISTINCT_ON, true},
+		{DIALECT_MSSQL, "16.0.1000.6", FEATURE_RETURNING, true},
+		{DIALECT_MSSQL, "16.0.1000.6", FEATURE_ILIKE, false},
+		{"oracle", "", FEATURE_RETURNING, false},
+	}
+
+	for _, test := range tests {
+		supports := NewBuilder(test.dialect).Version(test.version).Supports(test.feature)
+		if supports != test.expected {
+			t.Fatal("Expected ", test.dialect, " ", test.version, " supports ", test.feature, ": ", test.expected, " but found: ", supports)
+		}
+	}
+}
+
+func TestBuilderVersionUnsupported(t *testing.T) {
+	_, _, err := NewBuilder(DIALECT_SQLITE).Version("3.31.1").Table("users").Returning("id").InsertWithParams(map[string]string{"name": "Tom"})
+	if !errors.Is(err, ErrUnsupportedFeature) {
+		t.Fatal("Expected ErrUnsupportedFeature but found: ", err)
+	}
+
+	_, err = NewBuilder(DIALECT_SQLITE).Version("3.31.1").TableColumnDrop("users", "name")
+	if !errors.Is(err, ErrUnsupportedFeature) {
+		t.Fatal("Expected ErrUnsupportedFeature but found: ", err)
+	}
+
+	_, _, err = NewBuilder(DIALECT_MYSQL).
+		Version("5.7.44").
+		Table("orders").
+		With("recent", NewBuilder(DIALECT_MYSQL).Table("orders").Limit(10)).
+		SelectColumns("id").
+		Build()
+	if !errors.Is(err, ErrUnsupportedFeature) {
+		t.Fatal("Expected ErrUnsupportedFeature but found: ", err)
+	}
+
+	sql, err := NewBuilder(DIALECT_SQLITE).Version("3.45.1").TableColumnDrop("users", "name")
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	expected := `ALTER TABLE "users" DROP COLUMN "name";`
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+}
+
+func TestBuilderVersionAfterFeature(t *testing.T) {
+	_, _, err := NewBuilder(DIALECT_SQLITE).
+		Table("users").
+		Returning("id").
+		Version("3.31.1").
+		InsertWithParams(map[string]string{"name": "Tom"})
+
+	if !errors.Is(err, ErrUnsupportedFeature) {
+		t.Fatal("Expected ErrUnsupportedFeature but found: ", err)
+	}
+
+	_, _, err = NewBuilder(DIALECT_SQLITE).
+		Table("users").
+		FullJoin(Join{Table: "orders", On: []JoinOn{{Column: "users.id", OtherColumn: "orders.user_id"}}}).
+		Version("3.31.1").
+		Build()
+
+	if !errors.Is(err, ErrUnsupportedFeature) {
+		t.Fatal("Expected ErrUnsupportedFeature but found: ", err)
+	}
+
+	sql, _, err := NewBuilder(DIALECT_SQLITE).
+		Version("3.31.1").
+		Table("users").
+		Returning("id").
+		Version("3.45.1").
+		InsertWithParams(map[string]string{"name": "Tom"})
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	expected := `INSERT INTO "users" ("name") VALUES (?) RETURNING "id";`
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+}
+
+func TestBuilderVersionImmutable(t *testing.T) {
+	b := NewBuilder(DIALECT_SQLITE).Immutable()
+	old := b.Version("3.31.1")
+
+	if !b.Supports(FEATURE_RETURNING) {
+		t.Fatal("Expected the immutable builder to keep the latest version")
+	}
+
+	if old.Supports(FEATURE_RETURNING) {
+		t.Fatal("Expected the copy not to support RETURNING in SQLite 3.31.1")
+	}
+}
+
+func TestVersionAtLeast(t *testing.T) {
+	tests := []struct {
+		version  string
+		minimum  []int
+		expected bool
+	}{
+		{"", []int{8}, true},
+		{"unknown", []int{8}, true},
+		{"8", []int{8, 0}, true},
+		{"10.11.6-MariaDB", []int{8}, true},
+		{"3.8.2", []int{3, 8, 3}, false},
+		{"3.8.3", []int{3, 8, 3}, true},
+		{"3.10", []int{3, 8, 3}, true},
+		{" 9.5.1", []int{9, 5}, true},
+	}
+
+	for _, test := range tests {
+		if versionAtLeast(test.version, test.minimum...) != test.expected {
+			t.Fatal("Expected ", test.version, " at least ", test.minimum, ": ", test.expected)
+		}
+	}
+}
@@ -1,9 +1,29 @@
 package sb
 
 import (
+	"strconv"
 	"strings"
+
+	"github.com/samber/lo"
 )
 
+// Where represents a condition of the WHERE clause.
+//
+// A condition is either a column comparison (Column, Operator, Value),
+// a raw SQL string (Raw), or a parenthesised group of conditions (Children).
+// Besides the comparison operators, the OPERATOR_* constants are supported.
+// The operand may also be a subquery (Subquery).
+// Type is the logical operator ("AND" or "OR") joining the condition to
+// the previous one, and defaults to "AND".
+//
+//	Example:
+//	// WHERE "a" = '1' OR ("b" = '2' AND "c" = '3')
+//	builder.
+//		Where(Where{Column: "a", Operator: "=", Value: "1"}).
+//		OrWhereGroup(
+//			Where{Column: "b", Operator: "=", Value: "2"},
+//			Where{Column: "c", Operator: "=", Value: "3"},
+//		)
 type Where struct {
 	Raw      string
 	Column   string
@@ -11,6 +31,38 @@ type Where struct {
 	Type     string
 	Value    string
 	Children []Where
+
+	// Values are the typed operands of the condition. The IN and NOT IN
+	// operators use all of them, BETWEEN and NOT BETWEEN exactly two,
+	// and the other operators the first one, instead of Value.
+	// A nil value stands for NULL
+	Values []any
+
+	// Subquery is the operand of the condition instead of the values,
+	// for the EXISTS and NOT EXISTS operators it is the only operand
+	Subquery *Subquery
+
+	// Expression is the aggregate function compared instead of
+	// the column, in the having conditions (i.e. COUNT(*) > 5)
+	Expression *Expression
+}
+
+// OrWhere adds a where condition, joined to the previous one with OR
+func (b *Builder) OrWhere(where Where) BuilderInterface {
+	where.Type = "OR"
+	return b.Where(where)
+}
+
+// WhereGroup adds a parenthesised group of where conditions,
+// joined to the previous one with AND
+func (b *Builder) WhereGroup(wheres ...Where) BuilderInterface {
+	return b.Where(Where{Type: "AND", Children: wheres})
+}
+
+// OrWhereGroup adds a parenthesised group of where conditions,
+// joined to the previous one with OR
+func (b *Builder) OrWhereGroup(wheres ...Where) BuilderInterface {
+	return b.Where(Where{Type: "OR", Children: wheres})
 }
 
 /**
@@ -19,6 +71,18 @@ type Where struct {
  * @return string
  */
 func (b *Builder) whereToSql(wheres []Where) string {
+	sql := b.whereToSqlConditions(wheres)
+
+	if sql != "" {
+		return " WHERE " + sql
+	}
+
+	return ""
+}
+
+// whereToSqlConditions converts wheres to SQL conditions,
+// without the WHERE keyword
+func (b *Builder) whereToSqlConditions(wheres []Where) string {
 	sql := []string{}
 	for _, where := range wheres {
 		if where.Raw != "" {
@@ -30,82 +94,179 @@ func (b *Builder) whereToSql(wheres []Where) string {
 			where.Type = "AND"
 		}
 
-		if where.Column != "" {
-			sqlSingle := b.whereToSqlSingle(where.Column, where.Operator, where.Value)
+		sqlSingle := ""
 
-			if len(sql) > 0 {
-				sql = append(sql, where.Type+" "+sqlSingle)
-			} else {
-				sql = append(sql, sqlSingle)
+		if where.Column != "" || where.Subquery != nil || where.Expression != nil {
+			sqlSingle = b.whereToSqlSingle(where)
+		} else if len(where.Children) > 0 {
+			sqlSingle = b.whereToSqlConditions(where.Children)
+			if sqlSingle != "" {
+				sqlSingle = "(" + sqlSingle + ")"
 			}
+		}
 
+		if sqlSingle == "" {
+			continue
+		}
+
+		if len(sql) > 0 {
+			sql = append(sql, where.Type+" "+sqlSingle)
+		} else {
+			sql = append(sql, sqlSingle)
 		}
-		// } else {
-		// 	$_sql = array();
-		// 	$all = $where['WHERE'];
-		// 	for ($k = 0; k < count($all); k++) {
-		// 		$w = $all[$k];
-		// 		$sqlSingle = $this->whereToSqlSingle($w['COLUMN'], $w['OPERATOR'], $w['VALUE']);
-		// 		if ($k == 0) {
-		// 			$_sql[] = $sqlSingle;
-		// 		} else {
-		// 			$_sql[] = $w['TYPE'] . " " . $sqlSingle;
-		// 		}
-		// 	}
-		// 	$_sql = (count($_sql) > 0) ? " (" . implode(" ", $_sql) . ")" : "";
-
-		// 	if ($i == 0) {
-		// 		$sql[] = $_sql;
-		// 	} else {
-		// 		$sql[] = $where['TYPE'] . " " . $_sql;
-		// 	}
-		// }
-	}
-
-	if len(sql) > 0 {
-		return " WHERE " + strings.Join(sql, " ")
 	}
 
-	return ""
+	return strings.Join(sql, " ")
 }
 
-func (b *Builder) whereToSqlSingle(column, operator, value string) string {
-	if operator == "==" || operator == "===" {
-		operator = "="
+func (b *Builder) whereToSqlSingle(where Where) string {
+	operator := b.operatorToSql(where.Operator)
+	columnQuoted := ""
+	if where.Expression != nil {
+		expression := *where.Expression
+		expression.Alias = ""
+		columnQuoted = b.expressionToSql(expression)
+	} else if where.Column != "" {
+		columnQuoted = b.quoteAllowedColumn(where.Column)
 	}
-	if operator == "!=" || operator == "!==" {
-		operator = "<>"
+
+	if where.Subquery != nil {
+		return b.whereSubqueryToSql(columnQuoted, operator, *where.Subquery)
 	}
-	columnQuoted := b.quoteColumn(column)
-	valueQuoted := b.quoteValue(value)
 
-	sql := ""
-	if b.Dialect == DIALECT_MYSQL {
-		if value == "NULL" && operator == "=" {
-			sql = columnQuoted + " IS NULL"
-		} else if value == "NULL" && operator == "<>" {
-			sql = columnQuoted + " IS NOT NULL"
-		} else {
-			sql = columnQuoted + " " + operator + " " + valueQuoted
+	switch operator {
+	case OPERATOR_IS_NULL, OPERATOR_IS_NOT_NULL:
+		return columnQuoted + " " + operator
+	case OPERATOR_IN, OPERATOR_NOT_IN:
+		return b.whereInToSql(columnQuoted, operator, where.Values)
+	case OPERATOR_BETWEEN, OPERATOR_NOT_BETWEEN:
+		if len(where.Values) != 2 {
+			b.fail(errInvalidQuery("operator " + operator + " requires exactly two values for column " + where.Column))
 		}
+		return columnQuoted + " " + operator + " " + b.quoteValueAny(where.Values[0]) + " AND " + b.quoteValueAny(where.Values[1])
+	case OPERATOR_ILIKE, OPERATOR_NOT_ILIKE:
+		if b.supports(FEATURE_ILIKE) {
+			return columnQuoted + " " + operator + " " + b.whereValueToSql(where)
+		}
+		// Without ILIKE both sides are lowercased
+		like := lo.Ternary(operator == OPERATOR_ILIKE, OPERATOR_LIKE, OPERATOR_NOT_LIKE)
+		return "LOWER(" + columnQuoted + ") " + like + " LOWER(" + b.whereValueToSql(where) + ")"
 	}
-	if b.Dialect == DIALECT_POSTGRES {
-		if value == "NULL" && operator == "=" {
-			sql = columnQuoted + " IS NULL"
-		} else if value == "NULL" && operator == "<>" {
-			sql = columnQuoted + " IS NOT NULL"
-		} else {
-			sql = columnQuoted + " " + operator + " " + valueQuoted
+
+	// A nil value, or the "NULL" string, is compared with IS (NOT) NULL
+	isNull := where.Value == "NULL"
+	if len(where.Values) > 0 {
+		isNull = isNullValue(where.Values[0])
+	}
+
+	if isNull && operator == "=" {
+		return columnQuoted + " IS NULL"
+	}
+
+	if isNull && operator == "<>" {
+		return columnQuoted + " IS NOT NULL"
+	}
+
+	return columnQuoted + " " + operator + " " + b.whereValueToSql(where)
+}
+
+// whereValueToSql converts the value of a single operand where to SQL.
+// The typed Values take precedence over the string Value
+func (b *Builder) whereValueToSql(where Where) string {
+	if len(where.Values) > 0 {
+		return b.quoteValueAny(where.Values[0])
+	}
+
+	return b.quoteValue(where.Value)
+}
+
+// whereSubqueryToSql converts a where with a subquery operand to SQL
+func (b *Builder) whereSubqueryToSql(columnQuoted string, operator string, subquery Subquery) string {
+	if operator == OPERATOR_EXISTS || operator == OPERATOR_NOT_EXISTS {
+		return operator + " " + b.subqueryToSql(subquery)
+	}
+
+	return columnQuoted + " " + operator + " " + b.subqueryToSql(subquery)
+}
+
+// whereInToSql converts an IN or NOT IN where to SQL. An empty list
+// is converted to an always false (IN) or always true (NOT IN) condition,
+// and nil values to an IS NULL (IN) or IS NOT NULL (NOT IN) condition
+func (b *Builder) whereInToSql(columnQuoted string, operator string, values []any) string {
+	hasNull := false
+	valuesQuoted := []string{}
+
+	for _, value := range values {
+		if isNullValue(value) {
+			hasNull = true
+			continue
 		}
+		valuesQuoted = append(valuesQuoted, b.quoteValueAny(value))
 	}
-	if b.Dialect == DIALECT_SQLITE {
-		if value == "NULL" && operator == "=" {
-			sql = columnQuoted + " IS NULL"
-		} else if value == "NULL" && operator == "<>" {
-			sql = columnQuoted + " IS NOT NULL"
-		} else {
-			sql = columnQuoted + " " + operator + " " + valueQuoted
+
+	if operator == OPERATOR_IN {
+		sql := lo.Ternary(len(valuesQuoted) > 0, columnQuoted+" IN ("+strings.Join(valuesQuoted, ", ")+")", "1 = 0")
+
+		if !hasNull {
+			return sql
 		}
+
+		if len(valuesQuoted) == 0 {
+			return columnQuoted + " IS NULL"
+		}
+
+		return "(" + sql + " OR " + columnQuoted + " IS NULL)"
 	}
-	return sql
+
+	sql := lo.Ternary(len(valuesQuoted) > 0, columnQuoted+" NOT IN ("+strings.Join(valuesQuoted, ", ")+")", "1 = 1")
+
+	if !hasNull {
+		return sql
+	}
+
+	if len(valuesQuoted) == 0 {
+		return columnQuoted + " IS NOT NULL"
+	}
+
+	return "(" + sql + " AND " + columnQuoted + " IS NOT NULL)"
+}
+
+// comparisonOperators are the comparison operators allowed in the conditions,
+// with the OPERATOR_* constants
+var comparisonOperators = []string{
+	"=", "<>", "<", "<=", ">", ">=",
+	OPERATOR_BETWEEN,
+	OPERATOR_EXISTS,
+	OPERATOR_ILIKE,
+	OPERATOR_IN,
+	OPERATOR_IS_NOT_NULL,
+	OPERATOR_IS_NULL,
+	OPERATOR_LIKE,
+	OPERATOR_NOT_BETWEEN,
+	OPERATOR_NOT_EXISTS,
+	OPERATOR_NOT_ILIKE,
+	OPERATOR_NOT_IN,
+	OPERATOR_NOT_LIKE,
+}
+
+// operatorToSql converts the comparison operator aliases
+// (==, ===, !=, !==) to their SQL equivalents, and normalizes
+// the keyword operators (i.e. "not in") to uppercase. Any other
+// operator fails, as it is not quoted in the SQL
+func (b *Builder) operatorToSql(operator string) string {
+	operator = strings.Join(strings.Fields(strings.ToUpper(operator)), " ")
+
+	if operator == "" || operator == "==" || operator == "===" {
+		return "="
+	}
+
+	if operator == "!=" || operator == "!==" {
+		return "<>"
+	}
+
+	if !lo.Contains(comparisonOperators, operator) {
+		b.fail(errInvalidQuery("operator " + strconv.Quote(operator) + " is not supported"))
+	}
+
+	return operator
 }
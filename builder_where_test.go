@@ -0,0 +1,265 @@
+package sb
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBuilderWhereChildren(t *testing.T) {
+	sql := NewBuilder(DIALECT_SQLITE).
+		Table("users").
+		Where(Where{Column: "status", Operator: "=", Value: "active"}).
+		Where(Where{
+			Children: []Where{
+				{Column: "a", Operator: "=", Value: "1"},
+				{
+					Type: "OR",
+					Children: []Where{
+						{Column: "b", Operator: "=", Value: "2"},
+						{Column: "c", Operator: "=", Value: "3"},
+					},
+				},
+			},
+		}).
+		Select([]string{})
+
+	expected := `SELECT * FROM "users" WHERE "status" = 'active' AND ("a" = '1' OR ("b" = '2' AND "c" = '3'));`
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+}
+
+func TestBuilderWhereGroupHelpers(t *testing.T) {
+	sql := NewBuilder(DIALECT_MYSQL).
+		Table("users").
+		Where(Where{Column: "a", Operator: "=", Value: "1"}).
+		OrWhere(Where{Column: "a", Operator: "=", Value: "2"}).
+		WhereGroup(
+			Where{Column: "b", Operator: "=", Value: "3"},
+			Where{Column: "c", Operator: "=", Value: "4", Type: "OR"},
+		).
+		OrWhereGroup(
+			Where{Column: "d", Operator: "!=", Value: "5"},
+			Where{Column: "e", Operator: "=", Value: "NULL"},
+		).
+		Select([]string{"id"})
+
+	expected := "SELECT `id` FROM `users` WHERE `a` = '1' OR `a` = '2' AND (`b` = '3' OR `c` = '4') OR (`d` <> '5' AND `e` IS NULL);"
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+}
+
+func TestBuilderWhereGroupFirst(t *testing.T) {
+	sql := NewBuilder(DIALECT_POSTGRES).
+		Table("users").
+		OrWhereGroup(
+			Where{Column: "a", Operator: "=", Value: "1"},
+			Where{Column: "b", Operator: "=", Value: "2", Type: "OR"},
+		).
+		Where(Where{Column: "c", Operator: "=", Value: "3"}).
+		Delete()
+
+	expected := `DELETE FROM "users" WHERE ("a" = '1' OR "b" = '2') AND "c" = '3';`
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+}
+
+func TestBuilderWhereGroupEmpty(t *testing.T) {
+	sql := NewBuilder(DIALECT_SQLITE).
+		Table("users").
+		WhereGroup().
+		Where(Where{Column: "a", Operator: "=", Value: "1"}).
+		OrWhereGroup(Where{Children: []Where{}}).
+		Select([]string{})
+
+	expected := `SELECT * FROM "users" WHERE "a" = '1';`
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+}
+
+func TestBuilderWhereGroupWithParams(t *testing.T) {
+	sql, params, err := NewBuilder(DIALECT_POSTGRES).
+		Table("users").
+		Where(Where{Column: "a", Operator: "=", Value: "1"}).
+		OrWhereGroup(
+			Where{Column: "b", Operator: "=", Value: "2"},
+			Where{Column: "c", Operator: "=", Value: "3"},
+		).
+		SelectWithParams([]string{})
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	expected := `SELECT * FROM "users" WHERE "a" = $1 OR ("b" = $2 AND "c" = $3);`
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+
+	if len(params) != 3 || params[0] != "1" || params[1] != "2" || params[2] != "3" {
+		t.Fatal("Unexpected params: ", params)
+	}
+}
+
+func TestBuilderWhereOperators(t *testing.T) {
+	tests := []struct {
+		where    Where
+		expected string
+	}{
+		{Where{Column: "id", Operator: "in", Values: []any{1, 2, 3}}, `"id" IN (1, 2, 3)`},
+		{Where{Column: "id", Operator: "NOT IN", Values: []any{"a", "b"}}, `"id" NOT IN ('a', 'b')`},
+		{Where{Column: "id", Operator: OPERATOR_IN, Values: []any{}}, `1 = 0`},
+		{Where{Column: "id", Operator: OPERATOR_NOT_IN, Values: nil}, `1 = 1`},
+		{Where{Column: "id", Operator: OPERATOR_IN, Values: []any{1, nil}}, `("id" IN (1) OR "id" IS NULL)`},
+		{Where{Column: "id", Operator: OPERATOR_NOT_IN, Values: []any{1, nil}}, `("id" NOT IN (1) AND "id" IS NOT NULL)`},
+		{Where{Column: "id", Operator: OPERATOR_IN, Values: []any{nil}}, `"id" IS NULL`},
+		{Where{Column: "price", Operator: OPERATOR_BETWEEN, Values: []any{1.5, 10}}, `"price" BETWEEN 1.5 AND 10`},
+		{Where{Column: "created_at", Operator: "not between", Values: []any{"2020-01-01", "2021-01-01"}}, `"created_at" NOT BETWEEN '2020-01-01' AND '2021-01-01'`},
+		{Where{Column: "name", Operator: OPERATOR_LIKE, Value: "Jo%"}, `"name" LIKE 'Jo%'`},
+		{Where{Column: "name", Operator: OPERATOR_NOT_LIKE, Value: "Jo%"}, `"name" NOT LIKE 'Jo%'`},
+		{Where{Column: "name", Operator: OPERATOR_ILIKE, Value: "jo%"}, `LOWER("name") LIKE LOWER('jo%')`},
+		{Where{Column: "name", Operator: OPERATOR_NOT_ILIKE, Value: "jo%"}, `LOWER("name") NOT LIKE LOWER('jo%')`},
+		{Where{Column: "deleted_at", Operator: OPERATOR_IS_NULL}, `"deleted_at" IS NULL`},
+		{Where{Column: "deleted_at", Operator: "is not null"}, `"deleted_at" IS NOT NULL`},
+		{Where{Column: "deleted_at", Operator: "=", Values: []any{nil}}, `"deleted_at" IS NULL`},
+		{Where{Column: "deleted_at", Operator: "!=", Values: []any{nil}}, `"deleted_at" IS NOT NULL`},
+		{Where{Column: "age", Operator: ">=", Values: []any{18}}, `"age" >= 18`},
+	}
+
+	for _, test := range tests {
+		sql := NewBuilder(DIALECT_SQLITE).
+			Table("users").
+			Where(test.where).
+			Select([]string{})
+
+		expected := `SELECT * FROM "users" WHERE ` + test.expected + `;`
+		if sql != expected {
+			t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+		}
+	}
+}
+
+func TestBuilderWhereILikePostgres(t *testing.T) {
+	sql := NewBuilder(DIALECT_POSTGRES).
+		Table("users").
+		Where(Where{Column: "name", Operator: OPERATOR_ILIKE, Value: "jo%"}).
+		Select([]string{})
+
+	expected := `SELECT * FROM "users" WHERE "name" ILIKE 'jo%';`
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+}
+
+func TestBuilderWhereBetweenPanics(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("BETWEEN with one value must panic")
+		}
+	}()
+
+	NewBuilder(DIALECT_SQLITE).
+		Table("users").
+		Where(Where{Column: "age", Operator: OPERATOR_BETWEEN, Values: []any{1}}).
+		Select([]string{})
+}
+
+func TestBuilderWhereOperatorInvalid(t *testing.T) {
+	operators := []string{"= 1 OR 1 =", "; DROP TABLE users; --", "LIKE'", "NOT"}
+
+	for _, operator := range operators {
+		_, _, err := NewBuilder(DIALECT_SQLITE).
+			Table("users").
+			Where(Where{Column: "n", Operator: operator, Value: "1"}).
+			SelectWithParams([]string{})
+
+		if !errors.Is(err, ErrInvalidQuery) {
+			t.Fatal("Expected ErrInvalidQuery for operator ", operator, " but found: ", err)
+		}
+	}
+
+	_, _, err := NewBuilder(DIALECT_SQLITE).
+		Table("users").
+		Join(Join{Table: "orders", On: []JoinOn{{Column: "users.id", Operator: "= 1 OR 1 =", OtherColumn: "orders.user_id"}}}).
+		SelectWithParams([]string{})
+
+	if !errors.Is(err, ErrInvalidQuery) {
+		t.Fatal("Expected ErrInvalidQuery for the join operator but found: ", err)
+	}
+}
+
+func TestBuilderWhereOperatorsWithParams(t *testing.T) {
+	sql, params, err := NewBuilder(DIALECT_POSTGRES).
+		Table("users").
+		Where(Where{Column: "id", Operator: OPERATOR_IN, Values: []any{1, 2, nil}}).
+		Where(Where{Column: "age", Operator: OPERATOR_BETWEEN, Values: []any{18, 65}}).
+		SelectWithParams([]string{})
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	expected := `SELECT * FROM "users" WHERE ("id" IN ($1, $2) OR "id" IS NULL) AND "age" BETWEEN $3 AND $4;`
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+
+	if len(params) != 4 || params[0] != 1 || params[1] != 2 || params[2] != 18 || params[3] != 65 {
+		t.Fatal("Unexpected params: ", params)
+	}
+}
+
+func TestBuilderWhereOperatorsSqliteExec(t *testing.T) {
+	db, err := initSqliteDB("test_builder_where.db")
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	defer db.Close()
+
+	statements := []string{
+		NewBuilder(DIALECT_SQLITE).Table("users").
+			Column(Column{Name: "id", Type: COLUMN_TYPE_INTEGER, PrimaryKey: true}).
+			Column(Column{Name: "name", Type: COLUMN_TYPE_STRING, Nullable: true}).
+			Create(),
+		`INSERT INTO "users" ("id", "name") VALUES (1, 'John'), (2, 'jane'), (3, NULL)`,
+	}
+
+	for _, statement := range statements {
+		_, err = db.Exec(statement)
+		if err != nil {
+			t.Fatal("Error must be NIL but got: ", err.Error(), " for ", statement)
+		}
+	}
+
+	tests := []struct {
+		where    Where
+		expected int
+	}{
+		{Where{Column: "id", Operator: OPERATOR_IN, Values: []any{}}, 0},
+		{Where{Column: "id", Operator: OPERATOR_NOT_IN, Values: []any{}}, 3},
+		{Where{Column: "name", Operator: OPERATOR_IN, Values: []any{"John", nil}}, 2},
+		{Where{Column: "name", Operator: OPERATOR_NOT_IN, Values: []any{"John", nil}}, 1},
+		{Where{Column: "name", Operator: OPERATOR_ILIKE, Value: "J%"}, 2},
+		{Where{Column: "id", Operator: OPERATOR_BETWEEN, Values: []any{2, 3}}, 2},
+		{Where{Column: "name", Operator: OPERATOR_IS_NULL}, 1},
+	}
+
+	for _, test := range tests {
+		sql := NewBuilder(DIALECT_SQLITE).Table("users").Where(test.where).Select([]string{"id"})
+
+		rows, err := db.SelectToMapString(sql)
+
+		if err != nil {
+			t.Fatal("Error must be NIL but got: ", err.Error(), " for ", sql)
+		}
+
+		if len(rows) != test.expected {
+			t.Fatal("Expected ", test.expected, " rows but got ", len(rows), " for ", sql)
+		}
+	}
+}
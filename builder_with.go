@@ -0,0 +1,105 @@
+package sb
+
+import (
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// commonTableExpression is a named query of the WITH clause
+type commonTableExpression struct {
+	name      string
+	query     Subquery
+	recursive *Subquery
+}
+
+// With adds a common table expression, which the query
+// can then use by its name, like a table
+//
+//	Example:
+//	// WITH "active_users" AS (SELECT "id" FROM "users" WHERE "status" = 'active') SELECT * FROM "active_users";
+//	NewBuilder(DIALECT_SQLITE).
+//		With("active_users", NewBuilder(DIALECT_SQLITE).
+//			Table("users").
+//			Where(Where{Column: "status", Operator: "=", Value: "active"}).
+//			SelectColumns("id")).
+//		Table("active_users").
+//		Select([]string{})
+func (b *Builder) With(name string, query BuilderInterface) BuilderInterface {
+	if name == "" {
+		return b.errorAdd(errInvalidQuery("common table expression name is required"))
+	}
+
+	b = b.mutable()
+	b.sqlWith = append(b.sqlWith, commonTableExpression{
+		name:  name,
+		query: Subquery{Query: query},
+	})
+
+	return b
+}
+
+// WithRecursive adds a recursive common table expression, made of an
+// anchor query, and a recursive query, which references the expression
+// by its name. The two queries are combined with UNION ALL
+//
+//	Example:
+//	// WITH RECURSIVE "tree" AS (SELECT "id" FROM "categories" WHERE "parent_id" IS NULL
+//	// UNION ALL SELECT "categories"."id" FROM "categories" INNER JOIN "tree" ON "categories"."parent_id" = "tree"."id")
+//	// SELECT * FROM "tree";
+//	NewBuilder(DIALECT_SQLITE).
+//		WithRecursive("tree", NewBuilder(DIALECT_SQLITE).
+//			Table("categories").
+//			Where(Where{Column: "parent_id", Operator: OPERATOR_IS_NULL}).
+//			SelectColumns("id"), NewBuilder(DIALECT_SQLITE).
+//			Table("categories").
+//			Join(Join{
+//				Table: "tree",
+//				On:    []JoinOn{{Column: "categories.parent_id", OtherColumn: "tree.id"}},
+//			}).
+//			SelectColumns("categories.id")).
+//		Table("tree").
+//		Select([]string{})
+func (b *Builder) WithRecursive(name string, anchor BuilderInterface, recursive BuilderInterface) BuilderInterface {
+	if name == "" {
+		return b.errorAdd(errInvalidQuery("common table expression name is required"))
+	}
+
+	b = b.mutable()
+	b.sqlWith = append(b.sqlWith, commonTableExpression{
+		name:      name,
+		query:     Subquery{Query: anchor},
+		recursive: &Subquery{Query: recursive},
+	})
+
+	return b
+}
+
+// withToSql converts the common table expressions to a WITH clause.
+// MySQL, PostgreSQL and SQLite require the RECURSIVE keyword when
+// any of the expressions is recursive, MSSQL does not support it
+func (b *Builder) withToSql(ctes []commonTableExpression) string {
+	if !b.supports(FEATURE_CTE) {
+		b.fail(b.errUnsupportedFeature("common table expressions"))
+	}
+
+	isRecursive := lo.SomeBy(ctes, func(cte commonTableExpression) bool {
+		return cte.recursive != nil
+	})
+
+	sql := []string{}
+
+	for _, cte := range ctes {
+		query := b.subquerySelectToSql(cte.query)
+
+		if cte.recursive != nil {
+			query += " UNION ALL " + b.subquerySelectToSql(*cte.recursive)
+		}
+
+		sql = append(sql, b.quoteTable(cte.name)+" AS ("+query+")")
+	}
+
+	keyword := lo.Ternary(isRecursive && b.syntax() != DIALECT_MSSQL, "WITH RECURSIVE ", "WITH ")
+
+	return keyword + strings.Join(sql, ", ") + " "
+}
@@ -0,0 +1,139 @@
+package sb
+
+import (
+	"testing"
+)
+
+func TestBuilderWith(t *testing.T) {
+	tests := []struct {
+		dialect  string
+		expected string
+	}{
+		{DIALECT_MYSQL, "WITH `active_users` AS (SELECT `id` FROM `users` WHERE `status` = 'active') SELECT * FROM `active_users`;"},
+		{DIALECT_POSTGRES, `WITH "active_users" AS (SELECT "id" FROM "users" WHERE "status" = 'active') SELECT * FROM "active_users";`},
+		{DIALECT_SQLITE, `WITH "active_users" AS (SELECT "id" FROM "users" WHERE "status" = 'active') SELECT * FROM "active_users";`},
+	}
+
+	for _, test := range tests {
+		sql := NewBuilder(test.dialect).
+			With("active_users", NewBuilder(test.dialect).Table("users").Where(Where{Column: "status", Operator: "=", Value: "active"}).SelectColumns("id")).
+			Table("active_users").
+			Select([]string{})
+
+		if sql != test.expected {
+			t.Fatal("Expected:\n", test.expected, "\nbut found:\n", sql)
+		}
+	}
+}
+
+func TestBuilderWithRecursive(t *testing.T) {
+	sql := NewBuilder(DIALECT_POSTGRES).
+		With("roots", NewBuilder(DIALECT_POSTGRES).Table("categories").Where(Where{Column: "parent_id", Operator: OPERATOR_IS_NULL}).SelectColumns("id")).
+		WithRecursive("tree",
+			NewBuilder(DIALECT_POSTGRES).Table("roots").SelectColumns("id"),
+			NewBuilder(DIALECT_POSTGRES).Table("categories").Join(Join{
+				Table: "tree",
+				On:    []JoinOn{{Column: "categories.parent_id", OtherColumn: "tree.id"}},
+			}).SelectColumns("categories.id"),
+		).
+		Table("tree").
+		Select([]string{"id"})
+
+	expected := `WITH RECURSIVE "roots" AS (SELECT "id" FROM "categories" WHERE "parent_id" IS NULL), "tree" AS (SELECT "id" FROM "roots" UNION ALL SELECT "categories"."id" FROM "categories" INNER JOIN "tree" ON "categories"."parent_id" = "tree"."id") SELECT "id" FROM "tree";`
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+}
+
+func TestBuilderWithUpdateAndDelete(t *testing.T) {
+	expired := NewBuilder(DIALECT_SQLITE).Table("sessions").Where(Where{Column: "expires_at", Operator: "<", Value: "2020-01-01"}).SelectColumns("user_id")
+
+	sql := NewBuilder(DIALECT_SQLITE).
+		With("expired", expired).
+		Table("users").
+		Where(Where{Column: "id", Operator: OPERATOR_IN, Subquery: &Subquery{Query: NewBuilder(DIALECT_SQLITE).Table("expired")}}).
+		Update(map[string]string{"status": "inactive"})
+
+	expected := `WITH "expired" AS (SELECT "user_id" FROM "sessions" WHERE "expires_at" < '2020-01-01') UPDATE "users" SET "status"='inactive' WHERE "id" IN (SELECT * FROM "expired");`
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+
+	sql = NewBuilder(DIALECT_SQLITE).
+		With("expired", expired).
+		Table("users").
+		Where(Where{Column: "id", Operator: OPERATOR_IN, Subquery: &Subquery{Query: NewBuilder(DIALECT_SQLITE).Table("expired")}}).
+		Delete()
+
+	expected = `WITH "expired" AS (SELECT "user_id" FROM "sessions" WHERE "expires_at" < '2020-01-01') DELETE FROM "users" WHERE "id" IN (SELECT * FROM "expired");`
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+}
+
+func TestBuilderWithParams(t *testing.T) {
+	sql, params, err := NewBuilder(DIALECT_POSTGRES).
+		With("paid", NewBuilder(DIALECT_POSTGRES).Table("orders").Where(Where{Column: "status", Operator: "=", Value: "paid"})).
+		Table("paid").
+		Where(Where{Column: "total", Operator: ">", Values: []any{100}}).
+		SelectWithParams([]string{})
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	expected := `WITH "paid" AS (SELECT * FROM "orders" WHERE "status" = $1) SELECT * FROM "paid" WHERE "total" > $2;`
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+
+	if len(params) != 2 || params[0] != "paid" || params[1] != 100 {
+		t.Fatal("Unexpected params: ", params)
+	}
+}
+
+func TestBuilderWithRecursiveSqliteExec(t *testing.T) {
+	db, err := initSqliteDB("test_builder_with.db")
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	defer db.Close()
+
+	statements := []string{
+		NewBuilder(DIALECT_SQLITE).Table("categories").
+			Column(Column{Name: "id", Type: COLUMN_TYPE_INTEGER, PrimaryKey: true}).
+			Column(Column{Name: "parent_id", Type: COLUMN_TYPE_INTEGER, Nullable: true}).
+			Create(),
+		`INSERT INTO "categories" ("id", "parent_id") VALUES (1, NULL), (2, 1), (3, 2), (4, NULL)`,
+	}
+
+	for _, statement := range statements {
+		_, err = db.Exec(statement)
+		if err != nil {
+			t.Fatal("Error must be NIL but got: ", err.Error(), " for ", statement)
+		}
+	}
+
+	sql := NewBuilder(DIALECT_SQLITE).
+		WithRecursive("tree",
+			NewBuilder(DIALECT_SQLITE).Table("categories").Where(Where{Column: "id", Operator: "=", Values: []any{1}}).SelectColumns("id"),
+			NewBuilder(DIALECT_SQLITE).Table("categories").Join(Join{
+				Table: "tree",
+				On:    []JoinOn{{Column: "categories.parent_id", OtherColumn: "tree.id"}},
+			}).SelectColumns("categories.id"),
+		).
+		Table("tree").
+		Select([]string{"id"})
+
+	rows, err := db.SelectToMapString(sql)
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	if len(rows) != 3 {
+		t.Fatal("Expected 3 categories in the tree but got: ", rows)
+	}
+}
@@ -85,6 +85,126 @@ func TestColumnTypes(t *testing.T) {
 
 }
 
+func TestFunctions(t *testing.T) {
+	if FUNCTION_AVG != "AVG" {
+		t.Fatal(`FUNCTION_AVG must be "AVG"`)
+	}
+
+	if FUNCTION_COALESCE != "COALESCE" {
+		t.Fatal(`FUNCTION_COALESCE must be "COALESCE"`)
+	}
+
+	if FUNCTION_COUNT != "COUNT" {
+		t.Fatal(`FUNCTION_COUNT must be "COUNT"`)
+	}
+
+	if FUNCTION_DENSE_RANK != "DENSE_RANK" {
+		t.Fatal(`FUNCTION_DENSE_RANK must be "DENSE_RANK"`)
+	}
+
+	if FUNCTION_MAX != "MAX" {
+		t.Fatal(`FUNCTION_MAX must be "MAX"`)
+	}
+
+	if FUNCTION_MIN != "MIN" {
+		t.Fatal(`FUNCTION_MIN must be "MIN"`)
+	}
+
+	if FUNCTION_RANK != "RANK" {
+		t.Fatal(`FUNCTION_RANK must be "RANK"`)
+	}
+
+	if FUNCTION_ROW_NUMBER != "ROW_NUMBER" {
+		t.Fatal(`FUNCTION_ROW_NUMBER must be "ROW_NUMBER"`)
+	}
+
+	if FUNCTION_SUM != "SUM" {
+		t.Fatal(`FUNCTION_SUM must be "SUM"`)
+	}
+}
+
+func TestJoinTypes(t *testing.T) {
+	if JOIN_TYPE_CROSS != "CROSS" {
+		t.Fatal(`JOIN_TYPE_CROSS must be "CROSS"`)
+	}
+
+	if JOIN_TYPE_FULL != "FULL" {
+		t.Fatal(`JOIN_TYPE_FULL must be "FULL"`)
+	}
+
+	if JOIN_TYPE_INNER != "INNER" {
+		t.Fatal(`JOIN_TYPE_INNER must be "INNER"`)
+	}
+
+	if JOIN_TYPE_LEFT != "LEFT" {
+		t.Fatal(`JOIN_TYPE_LEFT must be "LEFT"`)
+	}
+
+	if JOIN_TYPE_RIGHT != "RIGHT" {
+		t.Fatal(`JOIN_TYPE_RIGHT must be "RIGHT"`)
+	}
+}
+
+func TestLockModes(t *testing.T) {
+	if LOCK_FOR_SHARE != "SHARE" {
+		t.Fatal(`LOCK_FOR_SHARE must be "SHARE"`)
+	}
+
+	if LOCK_FOR_UPDATE != "UPDATE" {
+		t.Fatal(`LOCK_FOR_UPDATE must be "UPDATE"`)
+	}
+}
+
+func TestOperators(t *testing.T) {
+	if OPERATOR_BETWEEN != "BETWEEN" {
+		t.Fatal(`OPERATOR_BETWEEN must be "BETWEEN"`)
+	}
+
+	if OPERATOR_EXISTS != "EXISTS" {
+		t.Fatal(`OPERATOR_EXISTS must be "EXISTS"`)
+	}
+
+	if OPERATOR_ILIKE != "ILIKE" {
+		t.Fatal(`OPERATOR_ILIKE must be "ILIKE"`)
+	}
+
+	if OPERATOR_IN != "IN" {
+		t.Fatal(`OPERATOR_IN must be "IN"`)
+	}
+
+	if OPERATOR_IS_NOT_NULL != "IS NOT NULL" {
+		t.Fatal(`OPERATOR_IS_NOT_NULL must be "IS NOT NULL"`)
+	}
+
+	if OPERATOR_IS_NULL != "IS NULL" {
+		t.Fatal(`OPERATOR_IS_NULL must be "IS NULL"`)
+	}
+
+	if OPERATOR_LIKE != "LIKE" {
+		t.Fatal(`OPERATOR_LIKE must be "LIKE"`)
+	}
+
+	if OPERATOR_NOT_BETWEEN != "NOT BETWEEN" {
+		t.Fatal(`OPERATOR_NOT_BETWEEN must be "NOT BETWEEN"`)
+	}
+
+	if OPERATOR_NOT_EXISTS != "NOT EXISTS" {
+		t.Fatal(`OPERATOR_NOT_EXISTS must be "NOT EXISTS"`)
+	}
+
+	if OPERATOR_NOT_ILIKE != "NOT ILIKE" {
+		t.Fatal(`OPERATOR_NOT_ILIKE must be "NOT ILIKE"`)
+	}
+
+	if OPERATOR_NOT_IN != "NOT IN" {
+		t.Fatal(`OPERATOR_NOT_IN must be "NOT IN"`)
+	}
+
+	if OPERATOR_NOT_LIKE != "NOT LIKE" {
+		t.Fatal(`OPERATOR_NOT_LIKE must be "NOT LIKE"`)
+	}
+}
+
 func TestCommon(t *testing.T) {
 	if YES != "yes" {
 		t.Fatal(`YES must be "yes"`)
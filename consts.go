@@ -6,6 +6,23 @@ const DIALECT_MYSQL = "mysql"
 const DIALECT_POSTGRES = "postgres"
 const DIALECT_SQLITE = "sqlite"
 
+// Features
+const FEATURE_CTE Feature = "common table expressions"
+const FEATURE_DISTINCT_ON Feature = "distinct on"
+const FEATURE_DROP_COLUMN Feature = "drop column"
+const FEATURE_FOR_SHARE Feature = "for share"
+const FEATURE_FULL_JOIN Feature = "full join"
+const FEATURE_IF_NOT_EXISTS Feature = "if not exists"
+const FEATURE_ILIKE Feature = "ilike"
+const FEATURE_NOWAIT Feature = "nowait"
+const FEATURE_RENAME_COLUMN Feature = "rename column"
+const FEATURE_RETURNING Feature = "returning"
+const FEATURE_RIGHT_JOIN Feature = "right join"
+const FEATURE_SKIP_LOCKED Feature = "skip locked"
+const FEATURE_TRUNCATE_CASCADE Feature = "truncate cascade"
+const FEATURE_UPSERT Feature = "upsert"
+const FEATURE_WINDOW_FUNCTIONS Feature = "window functions"
+
 // Column Attributes
 const COLUMN_ATTRIBUTE_AUTO = "auto"
 const COLUMN_ATTRIBUTE_DECIMALS = "decimals"
@@ -24,6 +41,49 @@ const COLUMN_TYPE_STRING = "string"
 const COLUMN_TYPE_TEXT = "text"
 const COLUMN_TYPE_LONGTEXT = "longtext"
 
+// Functions
+const FUNCTION_AVG = "AVG"
+const FUNCTION_COALESCE = "COALESCE"
+const FUNCTION_COUNT = "COUNT"
+const FUNCTION_DENSE_RANK = "DENSE_RANK"
+const FUNCTION_MAX = "MAX"
+const FUNCTION_MIN = "MIN"
+const FUNCTION_RANK = "RANK"
+const FUNCTION_ROW_NUMBER = "ROW_NUMBER"
+const FUNCTION_SUM = "SUM"
+
+// Foreign Key Actions
+const FOREIGN_KEY_ACTION_CASCADE = "CASCADE"
+const FOREIGN_KEY_ACTION_NO_ACTION = "NO ACTION"
+const FOREIGN_KEY_ACTION_RESTRICT = "RESTRICT"
+const FOREIGN_KEY_ACTION_SET_DEFAULT = "SET DEFAULT"
+const FOREIGN_KEY_ACTION_SET_NULL = "SET NULL"
+
+// Join Types
+const JOIN_TYPE_CROSS = "CROSS"
+const JOIN_TYPE_FULL = "FULL"
+const JOIN_TYPE_INNER = "INNER"
+const JOIN_TYPE_LEFT = "LEFT"
+const JOIN_TYPE_RIGHT = "RIGHT"
+
+// Lock Modes
+const LOCK_FOR_SHARE = "SHARE"
+const LOCK_FOR_UPDATE = "UPDATE"
+
+// Operators
+const OPERATOR_BETWEEN = "BETWEEN"
+const OPERATOR_EXISTS = "EXISTS"
+const OPERATOR_ILIKE = "ILIKE"
+const OPERATOR_IN = "IN"
+const OPERATOR_IS_NOT_NULL = "IS NOT NULL"
+const OPERATOR_IS_NULL = "IS NULL"
+const OPERATOR_LIKE = "LIKE"
+const OPERATOR_NOT_BETWEEN = "NOT BETWEEN"
+const OPERATOR_NOT_EXISTS = "NOT EXISTS"
+const OPERATOR_NOT_ILIKE = "NOT ILIKE"
+const OPERATOR_NOT_IN = "NOT IN"
+const OPERATOR_NOT_LIKE = "NOT LIKE"
+
 // Common
 const YES = "yes"
 const NO = "no"
@@ -19,7 +19,7 @@ func NewDatabaseFromDriver(driverName, dataSourceName string) (DatabaseInterface
 
 	return &Database{
 		db:             db,
-		databaseType:   driverName,
+		databaseType:   databaseType,
 		debug:          false,
 		sqlLogEnabled:  false,
 		sqlLog:         map[string]string{},
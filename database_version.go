@@ -0,0 +1,61 @@
+package sb
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Version returns the version of the database server, i.e. "8.0.36"
+// for MySQL or "3.45.1" for SQLite. It is queried on the first call,
+// and remembered for the later calls, the concurrent calls wait for it
+func (d *Database) Version() (version string, err error) {
+	d.versionMutex.Lock()
+	defer d.versionMutex.Unlock()
+
+	if d.version != "" {
+		return d.version, nil
+	}
+
+	if d.db == nil {
+		return "", errors.New("failed to detect the database version: no database connection")
+	}
+
+	var sqlStr string
+	switch d.databaseType {
+	case DIALECT_MYSQL:
+		sqlStr = "SELECT VERSION()"
+	case DIALECT_POSTGRES:
+		sqlStr = "SHOW server_version"
+	case DIALECT_SQLITE:
+		sqlStr = "SELECT sqlite_version()"
+	case DIALECT_MSSQL:
+		sqlStr = "SELECT CAST(SERVERPROPERTY('ProductVersion') AS NVARCHAR(128))"
+	default:
+		return "", errUnsupportedDialect(d.databaseType)
+	}
+
+	if d.tx != nil {
+		err = d.tx.QueryRow(sqlStr).Scan(&version)
+	} else {
+		err = d.db.QueryRow(sqlStr).Scan(&version)
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("failed to detect the database version: %w", err)
+	}
+
+	d.version = strings.TrimSpace(version)
+
+	return d.version, nil
+}
+
+// Supports checks if the database server supports a feature (one of the
+// FEATURE_* constants), taking its version into account, i.e. RETURNING
+// requires SQLite 3.35. If the version can not be detected, the latest
+// version of the dialect is assumed
+func (d *Database) Supports(feature Feature) bool {
+	version, _ := d.Version()
+
+	return NewBuilder(d.databaseType).Version(version).Supports(feature)
+}
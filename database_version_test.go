@@ -0,0 +1,103 @@
+package sb
+
+import (
+	"database/sql"
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestDatabaseVersion(t *testing.T) {
+	db, err := initSqliteDB("test_database_version.db")
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	version, err := db.Version()
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	if len(versionParts(version)) < 3 {
+		t.Fatal("Expected a SQLite version but found: ", version)
+	}
+
+	if !db.Supports(FEATURE_RETURNING) {
+		t.Fatal("Expected SQLite ", version, " to support RETURNING")
+	}
+
+	if db.Supports(FEATURE_DISTINCT_ON) {
+		t.Fatal("Expected SQLite not to support DISTINCT ON")
+	}
+}
+
+func TestDatabaseVersionNoConnection(t *testing.T) {
+	db := NewDatabase(nil, DIALECT_SQLITE)
+
+	_, err := db.Version()
+	if err == nil {
+		t.Fatal("Expected an error without a database connection")
+	}
+
+	if !db.Supports(FEATURE_RETURNING) {
+		t.Fatal("Expected the latest SQLite version when the version is not detected")
+	}
+}
+
+func TestDatabaseVersionConcurrent(t *testing.T) {
+	db, err := initSqliteDB("test_database_version.db")
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	versions := make([]string, 10)
+	errs := make([]error, 10)
+
+	var wg sync.WaitGroup
+
+	for i := range versions {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+			versions[i], errs[i] = db.Version()
+		}(i)
+	}
+
+	wg.Wait()
+
+	for i := range versions {
+		if errs[i] != nil {
+			t.Fatal("Error must be NIL but got: ", errs[i].Error())
+		}
+
+		if versions[i] != versions[0] {
+			t.Fatal("Expected the version ", versions[0], " but found: ", versions[i])
+		}
+	}
+}
+
+func TestDatabaseVersionDriverError(t *testing.T) {
+	sqlDB, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	defer sqlDB.Close()
+
+	tx, err := sqlDB.Begin()
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	if err := tx.Rollback(); err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	db := &Database{db: sqlDB, tx: tx, databaseType: DIALECT_SQLITE}
+
+	_, err = db.Version()
+	if !errors.Is(err, sql.ErrTxDone) {
+		t.Fatal("Expected the error to wrap sql.ErrTxDone but got: ", err)
+	}
+}
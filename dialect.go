@@ -0,0 +1,233 @@
+package sb
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Feature is a feature of the SQL, which not all the dialects support
+type Feature string
+
+// Dialect converts the dialect specific parts of the queries: the quoting,
+// the placeholders of the parameters, the pagination, the column types
+// and the supported features. The statements follow the syntax of one of
+// the built-in dialects, so a new dialect embeds the built-in dialect it
+// is closest to, and overrides what is different
+//
+//	Example:
+//	type CockroachDialect struct {
+//		sb.PostgresDialect
+//	}
+//
+//	func (CockroachDialect) Name() string {
+//		return "cockroachdb"
+//	}
+//
+//	sb.RegisterDialect(CockroachDialect{})
+//	sql := sb.NewBuilder("cockroachdb").Table("users").Select([]string{})
+type Dialect interface {
+	// Name returns the name of the dialect, used with NewBuilder
+	Name() string
+
+	// Syntax returns the built-in dialect (one of the DIALECT_* constants)
+	// whose statement syntax the dialect follows
+	Syntax() string
+
+	// QuoteIdentifier quotes a table or column name
+	QuoteIdentifier(identifier string) string
+
+	// ValidateIdentifier checks a table or column name can be used,
+	// i.e. it is within the length limit of the dialect
+	ValidateIdentifier(identifier string) error
+
+	// QuoteString converts a string to a string literal
+	QuoteString(value string) (string, error)
+
+	// Placeholder returns the placeholder of the bound
+	// parameter at the (1 based) position
+	Placeholder(position int) string
+
+	// LimitOffset converts the limit and the offset of a select to SQL.
+	// Zero (or less) means no limit, or no offset
+	LimitOffset(limit int64, offset int64, hasOrderBy bool) string
+
+	// ColumnSQLGenerator returns the generator of the column
+	// definitions, which maps the column types
+	ColumnSQLGenerator() ColumnSQLGenerator
+
+	// Supports checks if the dialect supports a feature in the server
+	// version. An empty version is taken as the latest version
+	Supports(feature Feature, version string) bool
+}
+
+var (
+	dialectsMutex sync.RWMutex
+	dialects      = map[string]Dialect{
+		DIALECT_MSSQL:    MSSQLDialect{},
+		DIALECT_MYSQL:    MySQLDialect{},
+		DIALECT_POSTGRES: PostgresDialect{},
+		DIALECT_SQLITE:   SQLiteDialect{},
+	}
+)
+
+// RegisterDialect registers a dialect by its name, so NewBuilder can use
+// it. A dialect registered with the name of another dialect replaces it.
+// It panics if the dialect is nil, has no name, or an unknown syntax
+func RegisterDialect(dialect Dialect) {
+	if dialect == nil {
+		panic("dialect is required")
+	}
+
+	if dialect.Name() == "" {
+		panic("dialect name is required")
+	}
+
+	switch dialect.Syntax() {
+	case DIALECT_MSSQL, DIALECT_MYSQL, DIALECT_POSTGRES, DIALECT_SQLITE:
+	default:
+		panic("dialect " + dialect.Name() + " has the unknown syntax " + dialect.Syntax())
+	}
+
+	dialectsMutex.Lock()
+	defer dialectsMutex.Unlock()
+
+	dialects[dialect.Name()] = dialect
+}
+
+// LookupDialect returns the dialect registered with the name
+func LookupDialect(name string) (Dialect, bool) {
+	dialectsMutex.RLock()
+	defer dialectsMutex.RUnlock()
+
+	dialect, ok := dialects[name]
+	return dialect, ok
+}
+
+// Dialects returns the sorted names of the registered dialects
+func Dialects() []string {
+	dialectsMutex.RLock()
+	defer dialectsMutex.RUnlock()
+
+	names := make([]string, 0, len(dialects))
+	for name := range dialects {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// validateIdentifier checks an identifier is not empty, has no NUL
+// characters, and its length is within the maximum length (if any)
+func validateIdentifier(dialect string, identifier string, length int, maxLength int) error {
+	if identifier == "" {
+		return errInvalidQuery("identifier is empty")
+	}
+
+	if strings.ContainsRune(identifier, 0) {
+		return errInvalidQuery("identifier " + strconv.Quote(identifier) + " contains a NUL character")
+	}
+
+	if maxLength > 0 && length > maxLength {
+		return errInvalidQuery("identifier " + identifier + " is longer than " + strconv.Itoa(maxLength) + " characters for dialect " + dialect)
+	}
+
+	return nil
+}
+
+// limitOffsetToSql converts the limit and offset to the
+// LIMIT ... OFFSET ... clause, used by most of the dialects
+func limitOffsetToSql(limit int64, offset int64) string {
+	sql := ""
+
+	if limit > 0 {
+		sql += " LIMIT " + strconv.FormatInt(limit, 10)
+	}
+
+	if offset > 0 {
+		sql += " OFFSET " + strconv.FormatInt(offset, 10)
+	}
+
+	return sql
+}
+
+// dialectOrFail returns the dialect of the builder,
+// and fails if the dialect is not registered
+func (b *Builder) dialectOrFail() Dialect {
+	if b.dialect == nil {
+		b.fail(errUnsupportedDialect(b.Dialect))
+	}
+
+	return b.dialect
+}
+
+// syntax returns the built-in dialect whose statement syntax the dialect
+// of the builder follows, or an empty string for an unknown dialect
+func (b *Builder) syntax() string {
+	if b.dialect == nil {
+		return ""
+	}
+
+	return b.dialect.Syntax()
+}
+
+// supports checks if the dialect of the builder supports a feature
+func (b *Builder) supports(feature Feature) bool {
+	return b.dialect != nil && b.dialect.Supports(feature, b.version)
+}
+
+// versionAtLeast checks if a server version (i.e. "8.0.36-0ubuntu",
+// "16.2 (Debian 16.2-1)" or "3.45.1") is at least the minimum version.
+// An empty, or unparsable, version is taken as the latest version
+func versionAtLeast(version string, minimum ...int) bool {
+	parts := versionParts(version)
+	if len(parts) == 0 {
+		return true
+	}
+
+	for i, minimumPart := range minimum {
+		part := 0
+		if i < len(parts) {
+			part = parts[i]
+		}
+
+		if part != minimumPart {
+			return part > minimumPart
+		}
+	}
+
+	return true
+}
+
+// versionParts returns the leading dot separated numbers of a version
+func versionParts(version string) []int {
+	version = strings.TrimSpace(version)
+	parts := []int{}
+
+	for _, field := range strings.Split(version, ".") {
+		digits := 0
+		for digits < len(field) && field[digits] >= '0' && field[digits] <= '9' {
+			digits++
+		}
+
+		if digits == 0 {
+			break
+		}
+
+		part, err := strconv.Atoi(field[:digits])
+		if err != nil {
+			break
+		}
+
+		parts = append(parts, part)
+
+		if digits < len(field) {
+			break
+		}
+	}
+
+	return parts
+}
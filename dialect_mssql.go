@@ -0,0 +1,72 @@
+package sb
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/samber/lo"
+)
+
+// MSSQLDialect is the Microsoft SQL Server dialect (DIALECT_MSSQL)
+type MSSQLDialect struct{}
+
+var _ Dialect = MSSQLDialect{}
+
+func (MSSQLDialect) Name() string {
+	return DIALECT_MSSQL
+}
+
+func (MSSQLDialect) Syntax() string {
+	return DIALECT_MSSQL
+}
+
+// QuoteIdentifier quotes the identifier in brackets, doubling the closing brackets inside it
+func (MSSQLDialect) QuoteIdentifier(identifier string) string {
+	return `[` + strings.ReplaceAll(identifier, `]`, `]]`) + `]`
+}
+
+// ValidateIdentifier checks the identifier is at most 128 characters
+func (d MSSQLDialect) ValidateIdentifier(identifier string) error {
+	return validateIdentifier(d.Name(), identifier, utf8.RuneCountInString(identifier), 128)
+}
+
+// QuoteString doubles the quotes in a unicode literal (N'...')
+func (MSSQLDialect) QuoteString(value string) (string, error) {
+	return `N'` + strings.ReplaceAll(value, `'`, `''`) + `'`, nil
+}
+
+func (MSSQLDialect) Placeholder(position int) string {
+	return "@p" + strconv.Itoa(position)
+}
+
+// LimitOffset pages with OFFSET ... FETCH NEXT, which requires an ORDER BY
+// clause, so when there is none, an ORDER BY (SELECT NULL) is added to keep
+// the existing order
+func (MSSQLDialect) LimitOffset(limit int64, offset int64, hasOrderBy bool) string {
+	if limit <= 0 && offset <= 0 {
+		return ""
+	}
+
+	sql := lo.Ternary(hasOrderBy, "", " ORDER BY (SELECT NULL)")
+	sql += " OFFSET " + strconv.FormatInt(max(offset, 0), 10) + " ROWS"
+
+	if limit > 0 {
+		sql += " FETCH NEXT " + strconv.FormatInt(limit, 10) + " ROWS ONLY"
+	}
+
+	return sql
+}
+
+func (MSSQLDialect) ColumnSQLGenerator() ColumnSQLGenerator {
+	return MSSQLColumnSQLGenerator{}
+}
+
+func (MSSQLDialect) Supports(feature Feature, version string) bool {
+	switch feature {
+	case FEATURE_DISTINCT_ON, FEATURE_ILIKE, FEATURE_TRUNCATE_CASCADE:
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,72 @@
+package sb
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// MySQLDialect is the MySQL dialect (DIALECT_MYSQL)
+type MySQLDialect struct{}
+
+var _ Dialect = MySQLDialect{}
+
+// mysqlEscapes are the characters escaped in MySQL strings
+var mysqlEscapes = strings.NewReplacer(
+	`\`, `\\`,
+	`'`, `''`,
+	"\x00", `\0`,
+	"\n", `\n`,
+	"\r", `\r`,
+	"\x1a", `\Z`,
+)
+
+func (MySQLDialect) Name() string {
+	return DIALECT_MYSQL
+}
+
+func (MySQLDialect) Syntax() string {
+	return DIALECT_MYSQL
+}
+
+// QuoteIdentifier quotes the identifier in backticks, doubling the backticks inside it
+func (MySQLDialect) QuoteIdentifier(identifier string) string {
+	return "`" + strings.ReplaceAll(identifier, "`", "``") + "`"
+}
+
+// ValidateIdentifier checks the identifier is at most 64 characters
+func (d MySQLDialect) ValidateIdentifier(identifier string) error {
+	return validateIdentifier(d.Name(), identifier, utf8.RuneCountInString(identifier), 64)
+}
+
+// QuoteString doubles the quotes, and backslash escapes the backslash
+// and the control characters. With NO_BACKSLASH_ESCAPES the escapes
+// are kept literally, but still can not end the literal
+func (MySQLDialect) QuoteString(value string) (string, error) {
+	return `'` + mysqlEscapes.Replace(value) + `'`, nil
+}
+
+func (MySQLDialect) Placeholder(position int) string {
+	return "?"
+}
+
+func (MySQLDialect) LimitOffset(limit int64, offset int64, hasOrderBy bool) string {
+	return limitOffsetToSql(limit, offset)
+}
+
+func (MySQLDialect) ColumnSQLGenerator() ColumnSQLGenerator {
+	return MySQLColumnSQLGenerator{}
+}
+
+// Supports checks the feature, the common table expressions, the window
+// functions, RENAME COLUMN, and the FOR SHARE, SKIP LOCKED and NOWAIT
+// locking are available since MySQL 8.0
+func (MySQLDialect) Supports(feature Feature, version string) bool {
+	switch feature {
+	case FEATURE_DISTINCT_ON, FEATURE_FULL_JOIN, FEATURE_ILIKE, FEATURE_RETURNING, FEATURE_TRUNCATE_CASCADE:
+		return false
+	case FEATURE_CTE, FEATURE_FOR_SHARE, FEATURE_NOWAIT, FEATURE_RENAME_COLUMN, FEATURE_SKIP_LOCKED, FEATURE_WINDOW_FUNCTIONS:
+		return versionAtLeast(version, 8)
+	}
+
+	return true
+}
@@ -0,0 +1,73 @@
+package sb
+
+import (
+	"strconv"
+	"strings"
+)
+
+// PostgresDialect is the PostgreSQL dialect (DIALECT_POSTGRES)
+type PostgresDialect struct{}
+
+var _ Dialect = PostgresDialect{}
+
+func (PostgresDialect) Name() string {
+	return DIALECT_POSTGRES
+}
+
+func (PostgresDialect) Syntax() string {
+	return DIALECT_POSTGRES
+}
+
+// QuoteIdentifier quotes the identifier in double quotes, doubling the double quotes inside it
+func (PostgresDialect) QuoteIdentifier(identifier string) string {
+	return `"` + strings.ReplaceAll(identifier, `"`, `""`) + `"`
+}
+
+// ValidateIdentifier checks the identifier is at most 63 bytes
+// (NAMEDATALEN - 1), as the longer identifiers are truncated
+func (d PostgresDialect) ValidateIdentifier(identifier string) error {
+	return validateIdentifier(d.Name(), identifier, len(identifier), 63)
+}
+
+// QuoteString doubles the quotes. A string with backslashes becomes an
+// escape string (E'...') with the backslashes doubled, which is correct
+// whether standard_conforming_strings is on or off. PostgreSQL strings
+// can not contain NUL characters
+func (d PostgresDialect) QuoteString(value string) (string, error) {
+	if strings.ContainsRune(value, 0) {
+		return "", errInvalidQuery("strings can not contain NUL characters for dialect " + d.Name())
+	}
+
+	escaped := strings.ReplaceAll(value, `'`, `''`)
+
+	if strings.Contains(value, `\`) {
+		return `E'` + strings.ReplaceAll(escaped, `\`, `\\`) + `'`, nil
+	}
+
+	return `'` + escaped + `'`, nil
+}
+
+func (PostgresDialect) Placeholder(position int) string {
+	return "$" + strconv.Itoa(position)
+}
+
+func (PostgresDialect) LimitOffset(limit int64, offset int64, hasOrderBy bool) string {
+	return limitOffsetToSql(limit, offset)
+}
+
+func (PostgresDialect) ColumnSQLGenerator() ColumnSQLGenerator {
+	return PostgreSQLColumnSQLGenerator{}
+}
+
+// Supports checks the feature, ON CONFLICT and SKIP LOCKED are available
+// since PostgreSQL 9.5, and CREATE TABLE IF NOT EXISTS since 9.1
+func (PostgresDialect) Supports(feature Feature, version string) bool {
+	switch feature {
+	case FEATURE_IF_NOT_EXISTS:
+		return versionAtLeast(version, 9, 1)
+	case FEATURE_SKIP_LOCKED, FEATURE_UPSERT:
+		return versionAtLeast(version, 9, 5)
+	}
+
+	return true
+}
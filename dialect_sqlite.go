@@ -0,0 +1,73 @@
+package sb
+
+import (
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// SQLiteDialect is the SQLite dialect (DIALECT_SQLITE)
+type SQLiteDialect struct{}
+
+var _ Dialect = SQLiteDialect{}
+
+func (SQLiteDialect) Name() string {
+	return DIALECT_SQLITE
+}
+
+func (SQLiteDialect) Syntax() string {
+	return DIALECT_SQLITE
+}
+
+// QuoteIdentifier quotes the identifier in double quotes, doubling the double quotes inside it
+func (SQLiteDialect) QuoteIdentifier(identifier string) string {
+	return `"` + strings.ReplaceAll(identifier, `"`, `""`) + `"`
+}
+
+// ValidateIdentifier checks the identifier is not empty, SQLite has no length limit
+func (d SQLiteDialect) ValidateIdentifier(identifier string) error {
+	return validateIdentifier(d.Name(), identifier, len(identifier), 0)
+}
+
+// QuoteString doubles the quotes, and concatenates the NUL
+// characters (which end the SQL text) as char(0)
+func (SQLiteDialect) QuoteString(value string) (string, error) {
+	parts := lo.Map(strings.Split(value, "\x00"), func(part string, _ int) string {
+		return `'` + strings.ReplaceAll(part, `'`, `''`) + `'`
+	})
+
+	return strings.Join(parts, " || char(0) || "), nil
+}
+
+func (SQLiteDialect) Placeholder(position int) string {
+	return "?"
+}
+
+func (SQLiteDialect) LimitOffset(limit int64, offset int64, hasOrderBy bool) string {
+	return limitOffsetToSql(limit, offset)
+}
+
+func (SQLiteDialect) ColumnSQLGenerator() ColumnSQLGenerator {
+	return SQLiteColumnSQLGenerator{}
+}
+
+// Supports checks the feature, many of them are only available
+// in the later SQLite versions, i.e. DROP COLUMN and RETURNING since 3.35
+func (SQLiteDialect) Supports(feature Feature, version string) bool {
+	switch feature {
+	case FEATURE_DISTINCT_ON, FEATURE_ILIKE, FEATURE_TRUNCATE_CASCADE:
+		return false
+	case FEATURE_CTE:
+		return versionAtLeast(version, 3, 8, 3)
+	case FEATURE_UPSERT:
+		return versionAtLeast(version, 3, 24)
+	case FEATURE_RENAME_COLUMN, FEATURE_WINDOW_FUNCTIONS:
+		return versionAtLeast(version, 3, 25)
+	case FEATURE_DROP_COLUMN, FEATURE_RETURNING:
+		return versionAtLeast(version, 3, 35)
+	case FEATURE_FULL_JOIN, FEATURE_RIGHT_JOIN:
+		return versionAtLeast(version, 3, 39)
+	}
+
+	return true
+}
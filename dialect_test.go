@@ -0,0 +1,129 @@
+package sb
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+// testDuckDBDialect is a dialect following the PostgreSQL syntax,
+// with question mark placeholders and without DISTINCT ON
+type testDuckDBDialect struct {
+	PostgresDialect
+}
+
+func (testDuckDBDialect) Name() string {
+	return "duckdb_test"
+}
+
+func (testDuckDBDialect) Placeholder(position int) string {
+	return "?"
+}
+
+func (d testDuckDBDialect) Supports(feature Feature, version string) bool {
+	return feature != FEATURE_DISTINCT_ON && d.PostgresDialect.Supports(feature, version)
+}
+
+func TestDialectBuiltIn(t *testing.T) {
+	for _, name := range []string{DIALECT_MSSQL, DIALECT_MYSQL, DIALECT_POSTGRES, DIALECT_SQLITE} {
+		dialect, ok := LookupDialect(name)
+		if !ok {
+			t.Fatal("Expected dialect to be registered: ", name)
+		}
+
+		if dialect.Name() != name || dialect.Syntax() != name {
+			t.Fatal("Expected dialect name and syntax ", name, " but found: ", dialect.Name(), dialect.Syntax())
+		}
+	}
+}
+
+func TestDialectRegister(t *testing.T) {
+	RegisterDialect(testDuckDBDialect{})
+
+	if !slices.Contains(Dialects(), "duckdb_test") {
+		t.Fatal("Expected duckdb_test in the dialects but found: ", Dialects())
+	}
+
+	sql, params, err := NewBuilder("duckdb_test").
+		Table("users").
+		Where(Where{Column: "name", Operator: "=", Value: "Tom"}).
+		Limit(10).
+		SelectWithParams([]string{"id"})
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	expected := `SELECT "id" FROM "users" WHERE "name" = ? LIMIT 10;`
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+
+	if len(params) != 1 || params[0] != "Tom" {
+		t.Fatal("Expected params [Tom] but found: ", params)
+	}
+
+	_, _, err = NewBuilder("duckdb_test").Table("users").DistinctOn("name").Build()
+	if !errors.Is(err, ErrUnsupportedFeature) {
+		t.Fatal("Expected ErrUnsupportedFeature but found: ", err)
+	}
+
+	sql = NewBuilder("duckdb_test").
+		Table("users").
+		Where(Where{Column: "name", Operator: OPERATOR_ILIKE, Value: "t%"}).
+		Select([]string{"id"})
+
+	expected = `SELECT "id" FROM "users" WHERE "name" ILIKE 't%';`
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+}
+
+func TestDialectRegisterInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		dialect Dialect
+	}{
+		{"nil", nil},
+		{"no name", testNamedDialect{name: ""}},
+		{"unknown syntax", testNamedDialect{name: "unknown_syntax_test", syntax: "oracle"}},
+	}
+
+	for _, test := range tests {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatal("Expected a panic for the dialect: ", test.name)
+				}
+			}()
+
+			RegisterDialect(test.dialect)
+		}()
+	}
+
+	if _, ok := LookupDialect("unknown_syntax_test"); ok {
+		t.Fatal("Expected unknown_syntax_test not to be registered")
+	}
+}
+
+func TestDialectUnknown(t *testing.T) {
+	_, _, err := NewBuilder("oracle").Table("users").SelectColumns("id").Build()
+	if !errors.Is(err, ErrUnsupportedDialect) {
+		t.Fatal("Expected ErrUnsupportedDialect but found: ", err)
+	}
+}
+
+// testNamedDialect is a SQLite dialect with a configurable name and syntax
+type testNamedDialect struct {
+	SQLiteDialect
+	name   string
+	syntax string
+}
+
+func (d testNamedDialect) Name() string {
+	return d.name
+}
+
+func (d testNamedDialect) Syntax() string {
+	return d.syntax
+}
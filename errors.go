@@ -0,0 +1,109 @@
+package sb
+
+import (
+	"errors"
+	"fmt"
+)
+
+// The errors of the builder. The returned errors wrap them with
+// the details, so they are checked with errors.Is
+//
+//	Example:
+//	sql, params, err := NewBuilder(DIALECT_MYSQL).SelectColumns("id").Build()
+//	if errors.Is(err, ErrNoTable) {
+//		// ...
+//	}
+var (
+	// ErrNoTable is returned when the statement requires a table, and none is set
+	ErrNoTable = errors.New("no table specified")
+
+	// ErrUnsupportedDialect is returned for a dialect unknown to the builder
+	ErrUnsupportedDialect = errors.New("unsupported dialect")
+
+	// ErrUnsupportedFeature is returned when the dialect does not support
+	// a feature used by the statement
+	ErrUnsupportedFeature = errors.New("unsupported feature")
+
+	// ErrInvalidQuery is returned when a part of the query is
+	// missing or invalid, i.e. a join without a table
+	ErrInvalidQuery = errors.New("invalid query")
+)
+
+// Err returns the first error of the methods building the query,
+// i.e. an unsupported dialect, or a feature not supported by the dialect.
+// The methods converting the query to SQL return it (or panic with it,
+// when they do not return an error)
+func (b *Builder) Err() error {
+	return b.err
+}
+
+// errorAdd keeps the first error of the methods building the query,
+// to be reported when the query is converted to SQL
+func (b *Builder) errorAdd(err error) BuilderInterface {
+	b = b.mutable()
+
+	if b.err == nil {
+		b.err = err
+	}
+
+	return b
+}
+
+// errNoTable returns the error for the method missing a table
+func errNoTable(method string) error {
+	return fmt.Errorf("in method %s() %w", method, ErrNoTable)
+}
+
+// errUnsupportedDialect returns the error for a dialect unknown to the builder
+func errUnsupportedDialect(dialect string) error {
+	return fmt.Errorf("%w: %s", ErrUnsupportedDialect, dialect)
+}
+
+// errUnsupportedFeature returns the error for a feature not supported by the dialect
+func (b *Builder) errUnsupportedFeature(feature string) error {
+	return fmt.Errorf("%w: %s for dialect %s", ErrUnsupportedFeature, feature, b.Dialect)
+}
+
+// errInvalidQuery returns the error for a missing or invalid part of the query
+func errInvalidQuery(details string) error {
+	return fmt.Errorf("%w: %s", ErrInvalidQuery, details)
+}
+
+// fail stops the conversion of the query to SQL with the error. The
+// methods returning an error recover it, the other methods panic with it
+func (b *Builder) fail(err error) {
+	panic(err)
+}
+
+// failOnError stops the conversion of the query to SQL
+// with the first error of the methods building the query
+func (b *Builder) failOnError() {
+	if b.err != nil {
+		b.fail(b.err)
+	}
+}
+
+// recoverError recovers a builder error into err, to be deferred by the
+// methods returning an error. Other panics are not recovered
+func recoverError(err *error) {
+	r := recover()
+
+	if r == nil {
+		return
+	}
+
+	if recovered, ok := r.(error); ok && isBuilderError(recovered) {
+		*err = recovered
+		return
+	}
+
+	panic(r)
+}
+
+// isBuilderError checks if the error is one of the builder errors
+func isBuilderError(err error) bool {
+	return errors.Is(err, ErrNoTable) ||
+		errors.Is(err, ErrUnsupportedDialect) ||
+		errors.Is(err, ErrUnsupportedFeature) ||
+		errors.Is(err, ErrInvalidQuery)
+}
@@ -0,0 +1,171 @@
+package sb
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBuildSelect(t *testing.T) {
+	sql, params, err := NewBuilder(DIALECT_POSTGRES).
+		Table("users").
+		SelectColumns("id", "name").
+		Where(Where{Column: "id", Operator: "=", Value: "1"}).
+		Build()
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	expected := `SELECT "id", "name" FROM "users" WHERE "id" = $1;`
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+
+	if len(params) != 1 || params[0] != "1" {
+		t.Fatal("Expected params [1] but found: ", params)
+	}
+}
+
+func TestBuildSelectColumnsWithSelect(t *testing.T) {
+	sql := NewBuilder(DIALECT_SQLITE).
+		Table("users").
+		SelectColumns("id").
+		Select([]string{"name"})
+
+	expected := `SELECT "id", "name" FROM "users";`
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+}
+
+func TestBuildErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		builder  BuilderInterface
+		expected error
+	}{
+		{"no table", NewBuilder(DIALECT_MYSQL), ErrNoTable},
+		{"unknown dialect", NewBuilder("oracle").Table("users"), ErrUnsupportedDialect},
+		{"full join", NewBuilder(DIALECT_MYSQL).Table("users").FullJoin(Join{Table: "orders", Using: []string{"user_id"}}), ErrUnsupportedFeature},
+		{"join without table", NewBuilder(DIALECT_MYSQL).Table("users").Join(Join{}), ErrInvalidQuery},
+		{"between", NewBuilder(DIALECT_MYSQL).Table("users").Where(Where{Column: "age", Operator: OPERATOR_BETWEEN, Values: []any{1}}), ErrInvalidQuery},
+		{"subquery error", NewBuilder(DIALECT_MYSQL).Table("users").Where(Where{Column: "id", Operator: OPERATOR_IN, Subquery: &Subquery{
+			Query: NewBuilder(DIALECT_MYSQL).Table("orders").Lock(Lock{Mode: "EXCLUSIVE"}),
+		}}), ErrInvalidQuery},
+	}
+
+	for _, test := range tests {
+		sql, params, err := test.builder.Build()
+
+		if !errors.Is(err, test.expected) {
+			t.Fatal("Error must be ", test.expected, " for ", test.name, " but got: ", err)
+		}
+
+		if sql != "" || params != nil {
+			t.Fatal("SQL and params must be empty for ", test.name, " but found: ", sql, params)
+		}
+	}
+}
+
+func TestBuildErrorsWithParams(t *testing.T) {
+	_, _, err := NewBuilder(DIALECT_MSSQL).Table("users").OrderBy("id", ASC).DeleteWithParams()
+	if !errors.Is(err, ErrUnsupportedFeature) {
+		t.Fatal("Error must be ErrUnsupportedFeature but got: ", err)
+	}
+
+	_, _, err = NewBuilder(DIALECT_SQLITE).UpdateWithParams(map[string]string{"name": "Tom"})
+	if !errors.Is(err, ErrNoTable) {
+		t.Fatal("Error must be ErrNoTable but got: ", err)
+	}
+
+	_, _, err = NewBuilder(DIALECT_SQLITE).Table("users").UpsertWithParams(map[string]string{"name": "Tom"}, Upsert{})
+	if !errors.Is(err, ErrInvalidQuery) {
+		t.Fatal("Error must be ErrInvalidQuery but got: ", err)
+	}
+
+	_, err = NewBuilder("oracle").TableColumnDrop("users", "name")
+	if !errors.Is(err, ErrUnsupportedDialect) {
+		t.Fatal("Error must be ErrUnsupportedDialect but got: ", err)
+	}
+}
+
+func TestBuildErrorsSql(t *testing.T) {
+	base := NewBuilder(DIALECT_MYSQL)
+
+	tests := map[string]func() (string, error){
+		"CreateSql":            func() (string, error) { return base.CreateSql() },
+		"CreateIfNotExistsSql": func() (string, error) { return base.CreateIfNotExistsSql() },
+		"CreateIndexSql":       func() (string, error) { return base.CreateIndexSql("idx_name", "name") },
+		"DeleteSql":            func() (string, error) { return base.DeleteSql() },
+		"DropSql":              func() (string, error) { return base.DropSql() },
+		"DropIfExistsSql":      func() (string, error) { return base.DropIfExistsSql() },
+		"InsertSql":            func() (string, error) { return base.InsertSql(map[string]string{"name": "Tom"}) },
+		"SelectSql":            func() (string, error) { return base.SelectSql([]string{}) },
+		"TruncateSql":          func() (string, error) { return base.TruncateSql() },
+		"UpdateSql":            func() (string, error) { return base.UpdateSql(map[string]string{"name": "Tom"}) },
+		"UpsertSql":            func() (string, error) { return base.UpsertSql(map[string]string{"name": "Tom"}, Upsert{}) },
+	}
+
+	for method, test := range tests {
+		sql, err := test()
+
+		if !errors.Is(err, ErrNoTable) {
+			t.Fatal("Method ", method, " must return ErrNoTable but got: ", err)
+		}
+
+		if sql != "" {
+			t.Fatal("SQL must be empty for ", method, " but found: ", sql)
+		}
+	}
+
+	_, err := NewBuilder(DIALECT_MYSQL).Table("users").TruncateSql(TruncateOptions{Cascade: true})
+	if !errors.Is(err, ErrUnsupportedFeature) {
+		t.Fatal("Error must be ErrUnsupportedFeature but got: ", err)
+	}
+
+	sql, err := NewBuilder(DIALECT_SQLITE).Table("users").DropIfExistsSql()
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	expected := `DROP TABLE IF EXISTS "users";`
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+}
+
+func TestBuilderPanicsWithError(t *testing.T) {
+	tests := map[string]func(){
+		"Select":      func() { NewBuilder(DIALECT_MYSQL).Select([]string{}) },
+		"Update":      func() { NewBuilder(DIALECT_MYSQL).Update(map[string]string{"name": "Tom"}) },
+		"CreateIndex": func() { NewBuilder(DIALECT_MYSQL).CreateIndex("idx_name", "name") },
+		"Create":      func() { NewBuilder(DIALECT_MYSQL).Create() },
+	}
+
+	for method, test := range tests {
+		func() {
+			defer func() {
+				err, ok := recover().(error)
+
+				if !ok || !errors.Is(err, ErrNoTable) {
+					t.Fatal("Method ", method, " must panic with ErrNoTable but got: ", err)
+				}
+
+				expected := "in method " + method + "() no table specified"
+				if err.Error() != expected {
+					t.Fatal("Expected:\n", expected, "\nbut found:\n", err.Error())
+				}
+			}()
+
+			test()
+		}()
+	}
+}
+
+func TestNewBuilderUnsupportedDialect(t *testing.T) {
+	builder := NewBuilder("oracle")
+
+	if !errors.Is(builder.Err(), ErrUnsupportedDialect) {
+		t.Fatal("Error must be ErrUnsupportedDialect but got: ", builder.Err())
+	}
+}
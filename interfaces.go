@@ -6,51 +6,204 @@ import (
 )
 
 type BuilderInterface interface {
+	// AllowColumns enables the strict mode, allowing only the given columns in the query
+	AllowColumns(columns ...string) BuilderInterface
+
+	// Build converts the select query to SQL, returning the values as bound parameters and the errors
+	Build() (sql string, params []any, err error)
+
+	// Clone returns an independent copy of the builder
+	Clone() BuilderInterface
+
 	// Column adds a column to the table
 	Column(column Column) BuilderInterface
 
 	// Create creates a table
 	Create() string
 
+	// CreateSql creates a table, returning the errors
+	CreateSql() (sqlString string, err error)
+
 	// CreateIfNotExists creates a table if it doesn't exist
 	CreateIfNotExists() string
 
+	// CreateIfNotExistsSql creates a table if it doesn't exist, returning the errors
+	CreateIfNotExistsSql() (sqlString string, err error)
+
 	// CreateIndex creates an index on the table
 	CreateIndex(indexName string, columnName ...string) string
 
+	// CreateIndexSql creates an index on the table, returning the errors
+	CreateIndexSql(indexName string, columnName ...string) (sqlString string, err error)
+
 	// Delete deletes a table
 	Delete() string
 
+	// DeleteSql deletes rows, returning the errors
+	DeleteSql() (sqlString string, err error)
+
+	// Distinct removes the duplicate rows from the select results
+	Distinct() BuilderInterface
+
+	// DistinctOn keeps the first row for each distinct value of the columns (PostgreSQL only)
+	DistinctOn(columns ...string) BuilderInterface
+
 	// Drop drops a table
 	Drop() string
 
+	// DropSql drops a table, returning the errors
+	DropSql() (sqlString string, err error)
+
 	// DropIfExists drops a table if it exists
 	DropIfExists() string
 
+	// DropIfExistsSql drops a table if it exists, returning the errors
+	DropIfExistsSql() (sqlString string, err error)
+
+	// DeleteWithParams deletes rows, returning the values as bound parameters
+	DeleteWithParams() (sql string, params []any, err error)
+
+	// Immutable returns a copy of the builder, where every change returns a changed copy
+	Immutable() BuilderInterface
+
 	// Insert inserts a row into the table
 	Insert(columnValuesMap map[string]string) string
 
+	// InsertAny inserts a row of typed values into the table
+	InsertAny(columnValuesMap map[string]any) string
+
+	// InsertAnyWithParams inserts a row of typed values, returning the values as bound parameters
+	InsertAnyWithParams(columnValuesMap map[string]any) (sql string, params []any, err error)
+
+	// InsertMany inserts multiple rows, split into statements within the dialect limits
+	InsertMany(rows []map[string]string) (sqls []string, err error)
+
+	// InsertManyWithParams inserts multiple rows, returning the values as bound parameters
+	InsertManyWithParams(rows []map[string]string) (sqls []string, params [][]any, err error)
+
+	// InsertSql inserts a row into the table, returning the errors
+	InsertSql(columnValuesMap map[string]string) (sqlString string, err error)
+
+	// InsertWithParams inserts a row, returning the values as bound parameters
+	InsertWithParams(columnValuesMap map[string]string) (sql string, params []any, err error)
+
+	// CrossJoin adds a cross join to the query
+	CrossJoin(join Join) BuilderInterface
+
+	// Err returns the first error of the methods building the query
+	Err() error
+
+	// Except keeps the rows of the select not returned by another select
+	Except(query Subquery) BuilderInterface
+
+	// ForeignKey adds a foreign key constraint to the table created
+	ForeignKey(foreignKey ForeignKey) BuilderInterface
+
+	// FromSubquery selects from a subquery instead of a table
+	FromSubquery(subquery Subquery) BuilderInterface
+
+	// FullJoin adds a full outer join to the query
+	FullJoin(join Join) BuilderInterface
+
+	// Intersect keeps the rows of the select also returned by another select
+	Intersect(query Subquery) BuilderInterface
+
+	// Join adds an inner join to the query
+	Join(join Join) BuilderInterface
+
+	// LeftJoin adds a left outer join to the query
+	LeftJoin(join Join) BuilderInterface
+
+	// Returning sets the columns of the affected rows returned by the statement
+	Returning(columns ...string) BuilderInterface
+
+	// RightJoin adds a right outer join to the query
+	RightJoin(join Join) BuilderInterface
+
 	// GroupBy groups the results by a column
 	GroupBy(groupBy GroupBy) BuilderInterface
 
+	// Having adds a condition filtering the groups
+	Having(having Where) BuilderInterface
+
 	// Limit limits the number of results
 	Limit(limit int64) BuilderInterface
 
+	// Lock locks the selected rows until the end of the transaction
+	Lock(lock Lock) BuilderInterface
+
 	// Offset offsets the results
 	Offset(offset int64) BuilderInterface
 
+	// OrWhere adds a where condition joined with OR
+	OrWhere(where Where) BuilderInterface
+
+	// OrWhereGroup adds a parenthesised group of where conditions joined with OR
+	OrWhereGroup(wheres ...Where) BuilderInterface
+
 	// OrderBy orders the results by a column
 	OrderBy(columnName string, sortDirection string) BuilderInterface
 
 	// Select selects the columns from the table
 	Select(columns []string) string
 
+	// SelectSql selects the columns from the table, returning the errors
+	SelectSql(columns []string) (sqlString string, err error)
+
+	// SelectColumns adds columns to the select list
+	SelectColumns(columns ...string) BuilderInterface
+
+	// SelectExpression adds an aggregate or window function to the select list
+	SelectExpression(expression Expression) BuilderInterface
+
+	// SelectSubquery adds a scalar subquery to the select list
+	SelectSubquery(subquery Subquery) BuilderInterface
+
+	// SelectWithParams selects the columns, returning the values as bound parameters
+	SelectWithParams(columns []string) (sql string, params []any, err error)
+
 	// Table sets the table name
 	Table(name string) BuilderInterface
 
+	// Union combines the select with another select, removing duplicates
+	Union(query Subquery) BuilderInterface
+
+	// UnionAll combines the select with another select, keeping duplicates
+	UnionAll(query Subquery) BuilderInterface
+
+	// Truncate removes all the rows from the table
+	Truncate(options ...TruncateOptions) string
+
+	// TruncateSql removes all the rows from the table, returning the errors
+	TruncateSql(options ...TruncateOptions) (sqlString string, err error)
+
 	// Update updates a row in the table
 	Update(columnValues map[string]string) string
 
+	// UpdateAny updates rows with typed values
+	UpdateAny(columnValues map[string]any) string
+
+	// UpdateAnyWithParams updates rows with typed values, returning the values as bound parameters
+	UpdateAnyWithParams(columnValues map[string]any) (sql string, params []any, err error)
+
+	// UpdateSql updates rows in the table, returning the errors
+	UpdateSql(columnValues map[string]string) (sqlString string, err error)
+
+	// UpdateWithParams updates rows, returning the values as bound parameters
+	UpdateWithParams(columnValues map[string]string) (sql string, params []any, err error)
+
+	// Upsert inserts a row, or updates the existing row on conflict
+	Upsert(columnValuesMap map[string]string, upsert Upsert) string
+
+	// UpsertSql upserts a row, returning the errors
+	UpsertSql(columnValuesMap map[string]string, upsert Upsert) (sqlString string, err error)
+
+	// UpsertWithParams upserts a row, returning the values as bound parameters
+	UpsertWithParams(columnValuesMap map[string]string, upsert Upsert) (sql string, params []any, err error)
+
+	// Version sets the version of the database server, to check the supported features
+	Version(version string) BuilderInterface
+
 	// View sets the view name
 	View(name string) BuilderInterface
 
@@ -63,12 +216,24 @@ type BuilderInterface interface {
 	// Where sets the where clause
 	Where(where Where) BuilderInterface
 
+	// With adds a common table expression
+	With(name string, query BuilderInterface) BuilderInterface
+
+	// WithRecursive adds a recursive common table expression
+	WithRecursive(name string, anchor BuilderInterface, recursive BuilderInterface) BuilderInterface
+
+	// WhereGroup adds a parenthesised group of where conditions joined with AND
+	WhereGroup(wheres ...Where) BuilderInterface
+
 	// TableColumnAdd adds a column to the table
 	TableColumnAdd(tableName string, column Column) (sqlString string, err error)
 
 	// TableColumnChange changes a column in the table
 	TableColumnChange(tableName string, column Column) (sqlString string, err error)
 
+	// Supports checks if the dialect supports a feature in the version set with Version
+	Supports(feature Feature) bool
+
 	// Table column drop drops a column
 	TableColumnDrop(tableName string, columnName string) (sqlString string, err error)
 
@@ -78,6 +243,12 @@ type BuilderInterface interface {
 	// TableColumnRename renames a column in a table
 	TableColumnRename(tableName, oldColumnName, newColumnName string) (sqlString string, err error)
 
+	// TableForeignKeyAdd adds a foreign key constraint to a table
+	TableForeignKeyAdd(tableName string, foreignKey ForeignKey) (sqlString string, err error)
+
+	// TableForeignKeyDrop drops a foreign key constraint from a table
+	TableForeignKeyDrop(tableName string, foreignKeyName string) (sqlString string, err error)
+
 	// TableRename renames a table
 	TableRename(oldTableName string, newTableName string) (sqlString string, err error)
 }
@@ -104,6 +275,14 @@ type DatabaseInterface interface {
 	// DebugEnable enables or disables debug
 	DebugEnable(debug bool)
 
+	// ExecToMapAny executes a statement returning rows (i.e. with RETURNING),
+	// and scans the rows into maps
+	ExecToMapAny(sqlStr string, args ...any) ([]map[string]any, error)
+
+	// ExecToMapString executes a statement returning rows (i.e. with RETURNING),
+	// and scans the rows into maps of strings
+	ExecToMapString(sqlStr string, args ...any) ([]map[string]string, error)
+
 	// ExecInTransaction executes a function in a transaction
 	ExecInTransaction(fn func(d *Database) error) (err error)
 
@@ -125,6 +304,12 @@ type DatabaseInterface interface {
 	SelectToMapAny(sqlStr string, args ...any) ([]map[string]any, error)
 	SelectToMapString(sqlStr string, args ...any) ([]map[string]string, error)
 
+	// Supports checks if the database server supports a feature, i.e. FEATURE_RETURNING
+	Supports(feature Feature) bool
+
 	// Tx the transaction
 	Tx() *sql.Tx
+
+	// Version the version of the database server, detected on the first call
+	Version() (version string, err error)
 }
@@ -73,15 +73,17 @@ func tableColumnsMysql(ctx database.QueryableContext, tableName string, commoniz
 		isUnique := columnKey == "UNI"
 		isNullable := columnNullable == "YES"
 		isAutoIncrement := strings.Contains(columnExtra, "auto_increment")
+		columnDefault, isDefaultExpression := mysqlColumnDefault(columnDefault, columnExtra)
 
 		column := Column{
-			Name:          columnName,
-			Type:          columnType,
-			PrimaryKey:    isPrimaryKey,
-			Unique:        isUnique,
-			Nullable:      isNullable,
-			Default:       columnDefault,
-			AutoIncrement: isAutoIncrement,
+			Name:              columnName,
+			Type:              columnType,
+			PrimaryKey:        isPrimaryKey,
+			Unique:            isUnique,
+			Nullable:          isNullable,
+			Default:           columnDefault,
+			DefaultExpression: isDefaultExpression,
+			AutoIncrement:     isAutoIncrement,
 		}
 
 		if length != "" && isNumeric(length) {
@@ -147,13 +149,16 @@ func tableColumnsSqlite(ctx database.QueryableContext, tableName string, commoni
 			isAutoIncrement = true
 		}
 
+		columnDefault, isDefaultExpression := sqliteColumnDefault(columnDefault)
+
 		column := Column{
-			Name:          columnName,
-			Type:          columnType,
-			PrimaryKey:    isPrimaryKey,
-			Nullable:      isNullable,
-			Default:       columnDefault,
-			AutoIncrement: isAutoIncrement,
+			Name:              columnName,
+			Type:              columnType,
+			PrimaryKey:        isPrimaryKey,
+			Nullable:          isNullable,
+			Default:           columnDefault,
+			DefaultExpression: isDefaultExpression,
+			AutoIncrement:     isAutoIncrement,
 		}
 
 		if length != "" && isNumeric(length) {
@@ -170,6 +175,41 @@ func tableColumnsSqlite(ctx database.QueryableContext, tableName string, commoni
 	return columns, nil
 }
 
+// mysqlColumnDefault converts the default returned by DESCRIBE, which is
+// the value of a literal, or an expression marked as DEFAULT_GENERATED
+// (since MySQL 8.0.13). The CURRENT_TIMESTAMP defaults of the earlier
+// versions are not marked, so they are recognized by the name
+func mysqlColumnDefault(value string, extra string) (columnDefault string, isExpression bool) {
+	if value == "" {
+		return "", false
+	}
+
+	isExpression = strings.Contains(strings.ToUpper(extra), "DEFAULT_GENERATED") ||
+		strings.HasPrefix(strings.ToUpper(value), "CURRENT_TIMESTAMP")
+
+	return value, isExpression
+}
+
+// sqliteColumnDefault converts the default returned by PRAGMA table_info,
+// which is the SQL of the default as written in CREATE TABLE. The string
+// literals are unquoted, everything else (i.e. 0, NULL, CURRENT_TIMESTAMP,
+// or datetime('now')) is an expression
+func sqliteColumnDefault(value string) (columnDefault string, isExpression bool) {
+	if value == "" {
+		return "", false
+	}
+
+	if len(value) >= 2 && strings.HasPrefix(value, "'") && strings.HasSuffix(value, "'") {
+		literal := value[1 : len(value)-1]
+
+		if !strings.Contains(strings.ReplaceAll(literal, "''", ""), "'") {
+			return strings.ReplaceAll(literal, "''", "'"), false
+		}
+	}
+
+	return value, true
+}
+
 func rawColumnProcess(columnType string) (scolumnType string, length string, decimals string) {
 	if !strings.Contains(columnType, "(") {
 		return columnType, "", ""
@@ -209,6 +209,149 @@ func TestTableColumnsSQLite(t *testing.T) {
 	}
 }
 
+func TestTableColumnsSQLiteDefaults(t *testing.T) {
+	columns := []Column{
+		{Name: "id", Type: COLUMN_TYPE_STRING, Length: 40, PrimaryKey: true},
+		{Name: "status", Type: COLUMN_TYPE_STRING, Length: 20, Default: "it's active"},
+		{Name: "state", Type: COLUMN_TYPE_STRING, Length: 20, Default: "Unknown (none)"},
+		{Name: "city", Type: COLUMN_TYPE_STRING, Length: 20, Default: "'s-Hertogenbosch"},
+		{Name: "count", Type: COLUMN_TYPE_INTEGER, Default: "0", DefaultExpression: true},
+		{Name: "price", Type: COLUMN_TYPE_FLOAT, Default: "-1.5", DefaultExpression: true},
+		{Name: "created_at", Type: COLUMN_TYPE_DATETIME, Default: "CURRENT_TIMESTAMP", DefaultExpression: true},
+		{Name: "updated_at", Type: COLUMN_TYPE_DATETIME, Default: "datetime('now')", DefaultExpression: true},
+		{Name: "deleted_at", Type: COLUMN_TYPE_DATETIME, Nullable: true, Default: "NULL", DefaultExpression: true},
+		{Name: "notes", Type: COLUMN_TYPE_TEXT, Nullable: true},
+	}
+
+	db, err := initSQLiteWithTable("test_table_columns_defaults", columns)
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	defer db.Close()
+
+	columns, err = TableColumns(database.Context(context.Background(), db), "test_table_columns_defaults", true)
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	// The round trip through Create keeps the defaults
+	err = TableCreate(db, "test_table_columns_defaults_copy", columns)
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	columnsCopy, err := TableColumns(database.Context(context.Background(), db), "test_table_columns_defaults_copy", true)
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	expecteds := map[string]Column{
+		"id":         {},
+		"status":     {Default: "it's active"},
+		"state":      {Default: "Unknown (none)"},
+		"city":       {Default: "'s-Hertogenbosch"},
+		"count":      {Default: "0", DefaultExpression: true},
+		"price":      {Default: "-1.5", DefaultExpression: true},
+		"created_at": {Default: "CURRENT_TIMESTAMP", DefaultExpression: true},
+		"updated_at": {Default: "datetime('now')", DefaultExpression: true},
+		"deleted_at": {Default: "NULL", DefaultExpression: true},
+		"notes":      {},
+	}
+
+	for _, column := range columnsCopy {
+		expected, found := expecteds[column.Name]
+
+		if !found {
+			t.Fatal("Error column '" + column.Name + "' must not be found")
+		}
+
+		if column.Default != expected.Default || column.DefaultExpression != expected.DefaultExpression {
+			t.Fatal("Error column '"+column.Name+"' default must be '"+expected.Default+"' (expression: ", expected.DefaultExpression, ") but got: '"+column.Default+"' (expression: ", column.DefaultExpression, ")")
+		}
+	}
+
+	_, err = db.Exec(`INSERT INTO "test_table_columns_defaults_copy" ("id") VALUES ('1');`)
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	rows, err := database.SelectToMapString(database.Context(context.Background(), db), `SELECT "status", "state", "city", "count" FROM "test_table_columns_defaults_copy";`)
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	if len(rows) != 1 || rows[0]["status"] != "it's active" || rows[0]["state"] != "Unknown (none)" || rows[0]["city"] != "'s-Hertogenbosch" || rows[0]["count"] != "0" {
+		t.Fatal("Error the defaults must be inserted but got: ", rows)
+	}
+}
+
+func TestTableColumnsMySQLDefaults(t *testing.T) {
+	columns := []Column{
+		{Name: "id", Type: COLUMN_TYPE_STRING, Length: 40, PrimaryKey: true},
+		{Name: "status", Type: COLUMN_TYPE_STRING, Length: 20, Default: "it's active"},
+		{Name: "state", Type: COLUMN_TYPE_STRING, Length: 20, Default: "Unknown (none)"},
+		{Name: "city", Type: COLUMN_TYPE_STRING, Length: 20, Default: "'s-Hertogenbosch"},
+		{Name: "count", Type: COLUMN_TYPE_INTEGER, Default: "0", DefaultExpression: true},
+		{Name: "created_at", Type: COLUMN_TYPE_DATETIME, Default: "CURRENT_TIMESTAMP", DefaultExpression: true},
+		{Name: "notes", Type: COLUMN_TYPE_STRING, Length: 20, Nullable: true},
+	}
+
+	db, err := initMySQLWithTable("test_table_columns_defaults", columns)
+
+	if TestsWithMySQL == false {
+		t.Log("TestsWithMySQL is false. Skipping TestTableColumnsMySQLDefaults test")
+		return
+	}
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	defer db.Close()
+
+	columns, err = TableColumns(database.Context(context.Background(), db), "test_table_columns_defaults", true)
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	// The round trip through Create keeps the defaults
+	err = TableDropIfExists(database.Context(context.Background(), db), "test_table_columns_defaults_copy")
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	err = TableCreate(db, "test_table_columns_defaults_copy", columns)
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	_, err = db.Exec("INSERT INTO `test_table_columns_defaults_copy` (`id`) VALUES ('1');")
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	rows, err := database.SelectToMapString(database.Context(context.Background(), db), "SELECT `status`, `state`, `city`, `count`, `created_at` FROM `test_table_columns_defaults_copy`;")
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	if len(rows) != 1 || rows[0]["status"] != "it's active" || rows[0]["state"] != "Unknown (none)" || rows[0]["city"] != "'s-Hertogenbosch" || rows[0]["count"] != "0" || rows[0]["created_at"] == "" {
+		t.Fatal("Error the defaults must be inserted but got: ", rows)
+	}
+}
+
 func _TestTableColumns_columns() []Column {
 	columns := []Column{
 		{
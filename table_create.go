@@ -6,30 +6,29 @@ import (
 	"github.com/gouniverse/base/database"
 )
 
-func TableCreateSql(db *sql.DB, tableName string, columns []Column) string {
+// TableCreateSql returns the SQL creating the table with the columns,
+// or the error of the builder, i.e. a column without a name or a type
+func TableCreateSql(db *sql.DB, tableName string, columns []Column) (string, error) {
 	databaseType := database.DatabaseType(db)
 
 	builder := NewBuilder(databaseType).Table(tableName)
 
 	for _, column := range columns {
-		builder.Column(column)
+		builder = builder.Column(column)
 	}
 
-	return builder.Create()
+	return builder.CreateSql()
 }
 
+// TableCreate creates the table with the columns
 func TableCreate(db *sql.DB, tableName string, columns []Column) error {
-	databaseType := database.DatabaseType(db)
-
-	builder := NewBuilder(databaseType).Table(tableName)
+	sqlTable, err := TableCreateSql(db, tableName, columns)
 
-	for _, column := range columns {
-		builder.Column(column)
+	if err != nil {
+		return err
 	}
 
-	sqlTable := builder.Create()
-
-	_, err := db.Exec(sqlTable)
+	_, err = db.Exec(sqlTable)
 
 	return err
 }
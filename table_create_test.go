@@ -0,0 +1,43 @@
+package sb
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gouniverse/base/database"
+)
+
+func TestTableCreateInvalidColumn(t *testing.T) {
+	db, err := database.Open(database.Options().
+		SetDatabaseType(database.DATABASE_TYPE_SQLITE).
+		SetDatabaseName(":memory:"))
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	defer db.Close()
+
+	columns := []Column{
+		{Name: "id", Type: COLUMN_TYPE_INTEGER, PrimaryKey: true},
+		{Name: "name"},
+	}
+
+	_, err = TableCreateSql(db, "test_table_create", columns)
+
+	if !errors.Is(err, ErrInvalidQuery) {
+		t.Fatal("Expected ErrInvalidQuery but got: ", err)
+	}
+
+	err = TableCreate(db, "test_table_create", columns)
+
+	if !errors.Is(err, ErrInvalidQuery) {
+		t.Fatal("Expected ErrInvalidQuery but got: ", err)
+	}
+
+	err = TableCreate(db, "", columns[:1])
+
+	if !errors.Is(err, ErrNoTable) {
+		t.Fatal("Expected ErrNoTable but got: ", err)
+	}
+}
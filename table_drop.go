@@ -13,7 +13,7 @@ func TableDropSql(ctx database.QueryableContext, tableName string) (string, erro
 
 	databaseType := database.DatabaseType(ctx.Queryable())
 
-	return NewBuilder(databaseType).Table(tableName).Drop(), nil
+	return NewBuilder(databaseType).Table(tableName).DropSql()
 }
 
 func TableDropIfExistsSql(ctx database.QueryableContext, tableName string) (string, error) {
@@ -23,7 +23,7 @@ func TableDropIfExistsSql(ctx database.QueryableContext, tableName string) (stri
 
 	databaseType := database.DatabaseType(ctx.Queryable())
 
-	return NewBuilder(databaseType).Table(tableName).DropIfExists(), nil
+	return NewBuilder(databaseType).Table(tableName).DropIfExistsSql()
 }
 
 func TableDrop(ctx database.QueryableContext, tableName string) error {
@@ -0,0 +1,195 @@
+package sb
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gouniverse/base/database"
+)
+
+// TableForeignKeyAdd adds a foreign key constraint to an existing table.
+// SQLite can not add constraints to a table, so there the table is rebuilt
+// with the constraint added to its definition, see sqliteTableRebuild
+func TableForeignKeyAdd(ctx database.QueryableContext, tableName string, foreignKey ForeignKey) error {
+	if ctx.Queryable() == nil {
+		return errors.New("queryable cannot be nil")
+	}
+
+	databaseType := database.DatabaseType(ctx.Queryable())
+
+	if databaseType != DIALECT_SQLITE {
+		sqlForeignKeyAdd, err := NewBuilder(databaseType).TableForeignKeyAdd(tableName, foreignKey)
+
+		if err != nil {
+			return err
+		}
+
+		_, err = ctx.Queryable().ExecContext(ctx, sqlForeignKeyAdd)
+
+		return err
+	}
+
+	constraint, err := sqliteForeignKeyToSql(foreignKey)
+
+	if err != nil {
+		return err
+	}
+
+	return sqliteTableRebuild(ctx, tableName, func(definition string) (string, error) {
+		return strings.TrimRight(definition, " \t\r\n") + ", " + constraint, nil
+	})
+}
+
+// TableForeignKeyDrop drops a foreign key constraint, by its name, from a table.
+// SQLite can not drop constraints from a table, so there the table is rebuilt
+// with the constraint removed from its definition, see sqliteTableRebuild
+func TableForeignKeyDrop(ctx database.QueryableContext, tableName string, foreignKeyName string) error {
+	if ctx.Queryable() == nil {
+		return errors.New("queryable cannot be nil")
+	}
+
+	databaseType := database.DatabaseType(ctx.Queryable())
+
+	if databaseType != DIALECT_SQLITE {
+		sqlForeignKeyDrop, err := NewBuilder(databaseType).TableForeignKeyDrop(tableName, foreignKeyName)
+
+		if err != nil {
+			return err
+		}
+
+		_, err = ctx.Queryable().ExecContext(ctx, sqlForeignKeyDrop)
+
+		return err
+	}
+
+	if foreignKeyName == "" {
+		return errInvalidQuery("in method TableForeignKeyDrop() no foreign key name specified")
+	}
+
+	return sqliteTableRebuild(ctx, tableName, func(definition string) (string, error) {
+		return sqliteForeignKeyDrop(definition, foreignKeyName)
+	})
+}
+
+// sqliteForeignKeyToSql converts a foreign key to a SQLite table constraint
+func sqliteForeignKeyToSql(foreignKey ForeignKey) (sql string, err error) {
+	defer recoverError(&err)
+
+	b := NewBuilder(DIALECT_SQLITE)
+
+	if err := b.validateForeignKey(foreignKey); err != nil {
+		return "", err
+	}
+
+	return b.foreignKeyToSql(foreignKey), nil
+}
+
+// sqliteForeignKeyDrop removes the foreign key constraint with the name from
+// the definition of a SQLite table, i.e. the table constraint
+// CONSTRAINT "fk" FOREIGN KEY ("user_id") REFERENCES "users" ("id"), or the
+// column constraint CONSTRAINT "fk" REFERENCES "users" ("id") ON DELETE CASCADE
+func sqliteForeignKeyDrop(definition string, foreignKeyName string) (string, error) {
+	tokens := sqliteTokenize(definition)
+
+	// The table constraints and the column definitions are separated by commas
+	items := [][]sqliteToken{{}}
+	commas := []sqliteToken{}
+
+	for _, token := range tokens {
+		if token.depth == 0 && token.text == "," {
+			items = append(items, []sqliteToken{})
+			commas = append(commas, token)
+			continue
+		}
+
+		items[len(items)-1] = append(items[len(items)-1], token)
+	}
+
+	isName := func(token sqliteToken) bool {
+		return strings.EqualFold(sqliteUnquote(token.text), foreignKeyName)
+	}
+
+	for i, item := range items {
+		for j := 0; j+2 < len(item); j++ {
+			if item[j].depth != 0 || !item[j].is("CONSTRAINT") || !isName(item[j+1]) {
+				continue
+			}
+
+			// The table constraint is removed with its comma
+			if j == 0 && item[2].is("FOREIGN") {
+				if i > 0 {
+					return definition[:commas[i-1].start] + definition[item[len(item)-1].end:], nil
+				}
+
+				if len(commas) > 0 {
+					return definition[:item[0].start] + strings.TrimLeft(definition[commas[0].end:], " \t\r\n"), nil
+				}
+
+				return "", errInvalidQuery("foreign key " + foreignKeyName + " is the only definition of the table")
+			}
+
+			if j > 0 && item[j+2].is("REFERENCES") {
+				end := sqliteForeignKeyClauseEnd(item, j+2)
+				return strings.TrimRight(definition[:item[j].start], " \t\r\n") + definition[item[end-1].end:], nil
+			}
+		}
+	}
+
+	return "", errInvalidQuery("foreign key " + foreignKeyName + " not found")
+}
+
+// sqliteForeignKeyClauseEnd returns the index of the token following the foreign
+// key clause starting with REFERENCES at index start, i.e. REFERENCES "users" ("id")
+// ON DELETE SET NULL MATCH SIMPLE NOT DEFERRABLE INITIALLY IMMEDIATE
+func sqliteForeignKeyClauseEnd(tokens []sqliteToken, start int) int {
+	depth := tokens[start].depth
+	i := start + 2
+
+	if i < len(tokens) && tokens[i].text == "(" {
+		for i < len(tokens) && !(tokens[i].depth == depth && tokens[i].text == ")") {
+			i++
+		}
+		i++
+	}
+
+	next := func(keywords ...string) bool {
+		if i >= len(tokens) {
+			return false
+		}
+
+		for _, keyword := range keywords {
+			if tokens[i].is(keyword) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	for i < len(tokens) {
+		switch {
+		case next("ON"):
+			i += 2 // ON DELETE, ON UPDATE
+
+			if next("SET", "NO") {
+				i++ // SET NULL, SET DEFAULT, NO ACTION
+			}
+
+			i++
+		case next("MATCH"):
+			i += 2
+		case next("NOT") && i+1 < len(tokens) && tokens[i+1].is("DEFERRABLE"):
+			i++
+		case next("DEFERRABLE"):
+			i++
+
+			if next("INITIALLY") {
+				i += 2
+			}
+		default:
+			return min(i, len(tokens))
+		}
+	}
+
+	return len(tokens)
+}
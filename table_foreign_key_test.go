@@ -0,0 +1,319 @@
+package sb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/gouniverse/base/database"
+	_ "modernc.org/sqlite"
+)
+
+func initSQLiteForeignKeys(t *testing.T, foreignKeysOn bool, statements ...string) *sql.DB {
+	db, err := sql.Open("sqlite", ":memory:")
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	// The in-memory database is per connection
+	db.SetMaxOpenConns(1)
+
+	if foreignKeysOn {
+		statements = append([]string{"PRAGMA foreign_keys = ON;"}, statements...)
+	}
+
+	for _, statement := range statements {
+		if _, err := db.Exec(statement); err != nil {
+			t.Fatal("Error must be NIL but got: ", err.Error(), " for: ", statement)
+		}
+	}
+
+	return db
+}
+
+func sqliteQueryCount(t *testing.T, db *sql.DB, query string) int {
+	count := 0
+
+	if err := db.QueryRow(query).Scan(&count); err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error(), " for: ", query)
+	}
+
+	return count
+}
+
+func TestTableForeignKeyAddSqlite(t *testing.T) {
+	db := initSQLiteForeignKeys(t, true,
+		`CREATE TABLE "users" ("id" INTEGER PRIMARY KEY);`,
+		`CREATE TABLE "orders" ("id" INTEGER PRIMARY KEY, "user_id" INTEGER NOT NULL, "code" TEXT COLLATE NOCASE CHECK ("code" <> ''), "total" REAL GENERATED ALWAYS AS (1.5) VIRTUAL) STRICT;`,
+		`CREATE INDEX "idx_orders_code" ON "orders" ("code");`,
+		`CREATE TABLE "audit" ("order_id" INTEGER);`,
+		`CREATE TRIGGER "trg_orders_insert" AFTER INSERT ON "orders" BEGIN INSERT INTO "audit" ("order_id") VALUES (new."id"); END;`,
+		`CREATE VIEW "view_orders" AS SELECT "id", "code" FROM "orders";`,
+		`INSERT INTO "users" ("id") VALUES (1);`,
+		`INSERT INTO "orders" ("id", "user_id", "code") VALUES (1, 1, 'a');`,
+	)
+
+	defer db.Close()
+
+	err := TableForeignKeyAdd(database.Context(context.Background(), db), "orders", ForeignKey{
+		Name:              "fk_orders_users",
+		Columns:           []string{"user_id"},
+		ReferencedTable:   "users",
+		ReferencedColumns: []string{"id"},
+		OnDelete:          FOREIGN_KEY_ACTION_CASCADE,
+	})
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	if count := sqliteQueryCount(t, db, `SELECT COUNT(*) FROM pragma_foreign_key_list('orders');`); count != 1 {
+		t.Fatal("Expected the foreign key to be added but found: ", count)
+	}
+
+	if count := sqliteQueryCount(t, db, `SELECT COUNT(*) FROM "sqlite_master" WHERE "name" IN ('idx_orders_code', 'trg_orders_insert', 'view_orders');`); count != 3 {
+		t.Fatal("Expected the index, the trigger and the view to be kept but found: ", count)
+	}
+
+	if count := sqliteQueryCount(t, db, `PRAGMA foreign_keys;`); count != 1 {
+		t.Fatal("Expected the foreign keys to be switched on again but found: ", count)
+	}
+
+	if count := sqliteQueryCount(t, db, `SELECT COUNT(*) FROM "view_orders" WHERE "code" = 'A';`); count != 1 {
+		t.Fatal("Expected the order to be kept, with the collation of the column, but found: ", count)
+	}
+
+	if _, err := db.Exec(`INSERT INTO "orders" ("id", "user_id", "code") VALUES (2, 1, '');`); err == nil {
+		t.Fatal("Expected the check constraint of the column to be kept")
+	}
+
+	if _, err := db.Exec(`INSERT INTO "orders" ("id", "user_id", "code") VALUES (2, 2, 'b');`); err == nil {
+		t.Fatal("Expected the foreign key to reject the order of an unknown user")
+	}
+
+	if _, err := db.Exec(`INSERT INTO "orders" ("id", "user_id", "code") VALUES (2, 1, 'b');`); err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	if count := sqliteQueryCount(t, db, `SELECT COUNT(*) FROM "audit";`); count != 2 {
+		t.Fatal("Expected the trigger to be created again but found: ", count)
+	}
+
+	if _, err := db.Exec(`DELETE FROM "users" WHERE "id" = 1;`); err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	if count := sqliteQueryCount(t, db, `SELECT COUNT(*) FROM "orders";`); count != 0 {
+		t.Fatal("Expected the orders to be deleted with the user but found: ", count)
+	}
+}
+
+func TestTableForeignKeyAddSqliteCheckFails(t *testing.T) {
+	db := initSQLiteForeignKeys(t, true,
+		`CREATE TABLE "users" ("id" INTEGER PRIMARY KEY);`,
+		`CREATE TABLE "orders" ("id" INTEGER PRIMARY KEY, "user_id" INTEGER NOT NULL);`,
+		`INSERT INTO "orders" ("id", "user_id") VALUES (1, 2);`,
+	)
+
+	defer db.Close()
+
+	err := TableForeignKeyAdd(database.Context(context.Background(), db), "orders", ForeignKey{
+		Columns:           []string{"user_id"},
+		ReferencedTable:   "users",
+		ReferencedColumns: []string{"id"},
+	})
+
+	if !errors.Is(err, ErrInvalidQuery) {
+		t.Fatal("Expected ErrInvalidQuery for the order of an unknown user but found: ", err)
+	}
+
+	if count := sqliteQueryCount(t, db, `SELECT COUNT(*) FROM pragma_foreign_key_list('orders');`); count != 0 {
+		t.Fatal("Expected the table to be rolled back but found foreign keys: ", count)
+	}
+
+	if count := sqliteQueryCount(t, db, `SELECT COUNT(*) FROM "sqlite_master" WHERE "name" = 'new_orders';`); count != 0 {
+		t.Fatal("Expected the new table to be rolled back but found: ", count)
+	}
+
+	if count := sqliteQueryCount(t, db, `PRAGMA foreign_keys;`); count != 1 {
+		t.Fatal("Expected the foreign keys to be switched on again but found: ", count)
+	}
+}
+
+func TestTableForeignKeyAddSqliteForeignKeysOff(t *testing.T) {
+	db := initSQLiteForeignKeys(t, false,
+		`CREATE TABLE "users" ("id" INTEGER PRIMARY KEY);`,
+		`CREATE TABLE "orders" ("id" INTEGER PRIMARY KEY, "user_id" INTEGER NOT NULL);`,
+	)
+
+	defer db.Close()
+
+	err := TableForeignKeyAdd(database.Context(context.Background(), db), "orders", ForeignKey{
+		Columns:           []string{"user_id"},
+		ReferencedTable:   "users",
+		ReferencedColumns: []string{"id"},
+	})
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	if count := sqliteQueryCount(t, db, `PRAGMA foreign_keys;`); count != 0 {
+		t.Fatal("Expected the foreign keys to be kept off but found: ", count)
+	}
+}
+
+func TestTableForeignKeyAddSqliteSchema(t *testing.T) {
+	db := initSQLiteForeignKeys(t, true,
+		`ATTACH DATABASE ':memory:' AS "aux";`,
+		`CREATE TABLE "aux"."users" ("id" INTEGER PRIMARY KEY);`,
+		`CREATE TABLE "aux"."orders" ("id" INTEGER PRIMARY KEY, "user_id" INTEGER NOT NULL);`,
+		`CREATE INDEX "aux"."idx_orders_user_id" ON "orders" ("user_id");`,
+		`INSERT INTO "aux"."users" ("id") VALUES (1);`,
+		`INSERT INTO "aux"."orders" ("id", "user_id") VALUES (1, 1);`,
+	)
+
+	defer db.Close()
+
+	err := TableForeignKeyAdd(database.Context(context.Background(), db), "aux.orders", ForeignKey{
+		Columns:           []string{"user_id"},
+		ReferencedTable:   "users",
+		ReferencedColumns: []string{"id"},
+	})
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	if count := sqliteQueryCount(t, db, `SELECT COUNT(*) FROM pragma_foreign_key_list('orders', 'aux');`); count != 1 {
+		t.Fatal("Expected the foreign key to be added but found: ", count)
+	}
+
+	if count := sqliteQueryCount(t, db, `SELECT COUNT(*) FROM "aux"."sqlite_master" WHERE "name" = 'idx_orders_user_id';`); count != 1 {
+		t.Fatal("Expected the index to be created again in the schema but found: ", count)
+	}
+
+	if count := sqliteQueryCount(t, db, `SELECT COUNT(*) FROM "main"."sqlite_master";`); count != 0 {
+		t.Fatal("Expected no tables in the main schema but found: ", count)
+	}
+
+	if count := sqliteQueryCount(t, db, `SELECT COUNT(*) FROM "aux"."orders";`); count != 1 {
+		t.Fatal("Expected the order to be kept but found: ", count)
+	}
+}
+
+func TestTableForeignKeyAddSqliteTransaction(t *testing.T) {
+	db := initSQLiteForeignKeys(t, true,
+		`CREATE TABLE "users" ("id" INTEGER PRIMARY KEY);`,
+		`CREATE TABLE "orders" ("id" INTEGER PRIMARY KEY, "user_id" INTEGER NOT NULL);`,
+	)
+
+	defer db.Close()
+
+	tx, err := db.Begin()
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	defer tx.Rollback()
+
+	err = TableForeignKeyAdd(database.Context(context.Background(), tx), "orders", ForeignKey{
+		Columns:           []string{"user_id"},
+		ReferencedTable:   "users",
+		ReferencedColumns: []string{"id"},
+	})
+
+	if !errors.Is(err, ErrInvalidQuery) {
+		t.Fatal("Expected ErrInvalidQuery in a transaction but found: ", err)
+	}
+}
+
+func TestTableForeignKeyDropSqlite(t *testing.T) {
+	db := initSQLiteForeignKeys(t, true,
+		`CREATE TABLE "users" ("id" INTEGER PRIMARY KEY);`,
+		`CREATE TABLE "products" ("id" INTEGER PRIMARY KEY);`,
+		`CREATE TABLE "orders" (`+
+			`"id" INTEGER PRIMARY KEY, `+
+			`"user_id" INTEGER CONSTRAINT "fk_orders_users" REFERENCES "users" ("id") ON DELETE CASCADE NOT DEFERRABLE NOT NULL, `+
+			`"product_id" INTEGER, `+
+			`CONSTRAINT "fk_orders_products" FOREIGN KEY ("product_id") REFERENCES "products" ("id") ON UPDATE SET NULL`+
+			`);`,
+		`INSERT INTO "users" ("id") VALUES (1);`,
+		`INSERT INTO "orders" ("id", "user_id") VALUES (1, 1);`,
+	)
+
+	defer db.Close()
+
+	ctx := database.Context(context.Background(), db)
+
+	if err := TableForeignKeyDrop(ctx, "orders", "fk_orders_products"); err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	if count := sqliteQueryCount(t, db, `SELECT COUNT(*) FROM pragma_foreign_key_list('orders') WHERE "table" = 'users';`); count != 1 {
+		t.Fatal("Expected only the foreign key to the products to be dropped but found: ", count)
+	}
+
+	if err := TableForeignKeyDrop(ctx, "orders", "FK_ORDERS_USERS"); err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	if count := sqliteQueryCount(t, db, `SELECT COUNT(*) FROM pragma_foreign_key_list('orders');`); count != 0 {
+		t.Fatal("Expected the foreign keys to be dropped but found: ", count)
+	}
+
+	if _, err := db.Exec(`INSERT INTO "orders" ("id", "user_id") VALUES (2, NULL);`); err == nil {
+		t.Fatal("Expected the NOT NULL of the column to be kept")
+	}
+
+	if count := sqliteQueryCount(t, db, `SELECT COUNT(*) FROM "orders";`); count != 1 {
+		t.Fatal("Expected the order to be kept but found: ", count)
+	}
+
+	err := TableForeignKeyDrop(ctx, "orders", "fk_unknown")
+
+	if !errors.Is(err, ErrInvalidQuery) {
+		t.Fatal("Expected ErrInvalidQuery for an unknown foreign key but found: ", err)
+	}
+}
+
+func TestSqliteForeignKeyDrop(t *testing.T) {
+	tests := []struct {
+		definition string
+		expected   string
+	}{
+		{
+			`"id" INTEGER, "user_id" INTEGER, CONSTRAINT "fk" FOREIGN KEY ("user_id") REFERENCES "users" ("id")`,
+			`"id" INTEGER, "user_id" INTEGER`,
+		},
+		{
+			`"id" INTEGER, CONSTRAINT [fk] FOREIGN KEY ("user_id") REFERENCES "users" ("id"), CHECK ("id" > 0)`,
+			`"id" INTEGER, CHECK ("id" > 0)`,
+		},
+		{
+			`"user_id" INTEGER CONSTRAINT fk REFERENCES users (id) ON DELETE SET DEFAULT ON UPDATE NO ACTION MATCH FULL DEFERRABLE INITIALLY DEFERRED DEFAULT 1, "id" INTEGER`,
+			`"user_id" INTEGER DEFAULT 1, "id" INTEGER`,
+		},
+		{
+			`"user_id" INTEGER CONSTRAINT "fk" REFERENCES "users" NOT NULL, -- fk
+"note" TEXT DEFAULT 'CONSTRAINT "fk" FOREIGN KEY'`,
+			`"user_id" INTEGER NOT NULL, -- fk
+"note" TEXT DEFAULT 'CONSTRAINT "fk" FOREIGN KEY'`,
+		},
+	}
+
+	for _, test := range tests {
+		definition, err := sqliteForeignKeyDrop(test.definition, "fk")
+
+		if err != nil {
+			t.Fatal("Error must be NIL but got: ", err.Error())
+		}
+
+		if definition != test.expected {
+			t.Fatal("Expected:\n", test.expected, "\nbut found:\n", definition)
+		}
+	}
+}
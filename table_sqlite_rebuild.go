@@ -0,0 +1,342 @@
+package sb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/gouniverse/base/database"
+	"github.com/samber/lo"
+)
+
+// sqliteTableRebuild changes the definition of a SQLite table, following the
+// procedure documented by SQLite (https://www.sqlite.org/lang_altertable.html#otheralter).
+// The table is created again with the definition of its CREATE TABLE statement,
+// as changed by change, so the columns, the constraints and the table options are
+// kept. The rows are copied, the indexes and the triggers of the table created again,
+// and the foreign keys checked, in a transaction. The foreign keys are switched off
+// meanwhile (on a connection, as they can not be switched off in a transaction),
+// and switched on again after, when they were on
+func sqliteTableRebuild(ctx database.QueryableContext, tableName string, change func(definition string) (string, error)) (err error) {
+	var conn *sql.Conn
+
+	switch queryable := ctx.Queryable().(type) {
+	case *sql.DB:
+		conn, err = queryable.Conn(ctx)
+
+		if err != nil {
+			return err
+		}
+
+		defer conn.Close()
+	case *sql.Conn:
+		conn = queryable
+	default:
+		return errInvalidQuery("table " + tableName + " must be rebuilt outside a transaction, as the foreign keys can not be switched off in it")
+	}
+
+	schema, table, found := strings.Cut(tableName, ".")
+
+	if !found {
+		schema, table = "main", tableName
+	}
+
+	quotedSchema := SQLiteDialect{}.QuoteIdentifier(schema)
+	quotedTable := quotedSchema + "." + SQLiteDialect{}.QuoteIdentifier(table)
+	quotedNewTable := quotedSchema + "." + SQLiteDialect{}.QuoteIdentifier("new_"+table)
+
+	// 1. Switch off the foreign keys, when they are on
+	foreignKeysOn := false
+
+	if err := conn.QueryRowContext(ctx, "PRAGMA foreign_keys;").Scan(&foreignKeysOn); err != nil {
+		return err
+	}
+
+	if foreignKeysOn {
+		if _, err := conn.ExecContext(ctx, "PRAGMA foreign_keys = OFF;"); err != nil {
+			return err
+		}
+
+		// 12. Switch the foreign keys on again
+		defer func() {
+			_, errOn := conn.ExecContext(context.Background(), "PRAGMA foreign_keys = ON;")
+			err = errors.Join(err, errOn)
+		}()
+	}
+
+	// 2. Start a transaction
+	tx, err := conn.BeginTx(ctx, nil)
+
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, tx.Rollback())
+		}
+	}()
+
+	// 3. Remember the CREATE TABLE statement, the columns, the indexes and the triggers
+	createSql := ""
+
+	err = tx.QueryRowContext(ctx, `SELECT "sql" FROM `+quotedSchema+`."sqlite_master" WHERE "type" = 'table' AND "name" = ? COLLATE NOCASE;`, table).Scan(&createSql)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return errInvalidQuery("table " + tableName + " not found")
+	}
+
+	if err != nil {
+		return err
+	}
+
+	columns, err := sqliteQueryStrings(ctx, tx, `SELECT "name" FROM pragma_table_xinfo(?, ?) WHERE "hidden" = 0;`, table, schema)
+
+	if err != nil {
+		return err
+	}
+
+	schemaSqls, err := sqliteQueryStrings(ctx, tx, `SELECT "sql" FROM `+quotedSchema+`."sqlite_master" WHERE "type" IN ('index', 'trigger') AND "tbl_name" = ? COLLATE NOCASE AND "sql" IS NOT NULL;`, table)
+
+	if err != nil {
+		return err
+	}
+
+	// 4. Create the new table, with the changed definition
+	open, closing, err := sqliteTableDefinition(createSql)
+
+	if err != nil {
+		return err
+	}
+
+	definition, err := change(createSql[open+1 : closing])
+
+	if err != nil {
+		return err
+	}
+
+	quotedColumns := strings.Join(lo.Map(columns, func(column string, _ int) string {
+		return SQLiteDialect{}.QuoteIdentifier(column)
+	}), ", ")
+
+	legacyAlterTableOn := false
+
+	if err = tx.QueryRowContext(ctx, "PRAGMA legacy_alter_table;").Scan(&legacyAlterTableOn); err != nil {
+		return err
+	}
+
+	// 5. Copy the rows, 6. drop the table, 7. rename the new table. The legacy
+	// rename keeps the views and the triggers of the other tables as they are,
+	// as they reference the table by its name, missing until the rename
+	statements := []string{
+		"CREATE TABLE " + quotedNewTable + "(" + definition + ")" + createSql[closing+1:] + ";",
+		"INSERT INTO " + quotedNewTable + " (" + quotedColumns + ") SELECT " + quotedColumns + " FROM " + quotedTable + ";",
+		"DROP TABLE " + quotedTable + ";",
+		"PRAGMA legacy_alter_table = ON;",
+		"ALTER TABLE " + quotedNewTable + " RENAME TO " + SQLiteDialect{}.QuoteIdentifier(table) + ";",
+	}
+
+	if !legacyAlterTableOn {
+		statements = append(statements, "PRAGMA legacy_alter_table = OFF;")
+	}
+
+	// 8. Create the indexes and the triggers again, in the schema of the table
+	for _, schemaSql := range schemaSqls {
+		statements = append(statements, sqliteSchemaObjectQualify(schemaSql, quotedSchema))
+	}
+
+	for _, statement := range statements {
+		if _, err = tx.ExecContext(ctx, statement); err != nil {
+			return err
+		}
+	}
+
+	// 10. Check the foreign keys, when they were on
+	if foreignKeysOn {
+		violations, err := sqliteQueryStrings(ctx, tx, `SELECT DISTINCT "table" || ' references ' || "parent" FROM pragma_foreign_key_check(NULL, ?);`, schema)
+
+		if err != nil {
+			return err
+		}
+
+		if len(violations) > 0 {
+			return errInvalidQuery("foreign key check failed for table " + tableName + ": " + strings.Join(violations, ", "))
+		}
+	}
+
+	// 11. Commit the transaction
+	return tx.Commit()
+}
+
+// sqliteQueryStrings returns the first column of the rows of the query
+func sqliteQueryStrings(ctx context.Context, tx *sql.Tx, query string, args ...any) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, query, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	values := []string{}
+
+	for rows.Next() {
+		value := ""
+
+		if err := rows.Scan(&value); err != nil {
+			return nil, err
+		}
+
+		values = append(values, value)
+	}
+
+	return values, rows.Err()
+}
+
+// sqliteTableDefinition returns the positions of the parentheses
+// around the definition of the table in its CREATE TABLE statement
+func sqliteTableDefinition(createSql string) (open int, closing int, err error) {
+	open = -1
+
+	for _, token := range sqliteTokenize(createSql) {
+		if token.text == "(" && token.depth == 0 && open == -1 {
+			open = token.start
+		}
+
+		if token.text == ")" && token.depth == 0 && open != -1 {
+			return open, token.start, nil
+		}
+	}
+
+	return 0, 0, errInvalidQuery("table definition not found in: " + createSql)
+}
+
+// sqliteSchemaObjectQualify qualifies the name of the index or the trigger
+// created by the statement with the schema, unless it is qualified already
+func sqliteSchemaObjectQualify(createSql string, quotedSchema string) string {
+	tokens := sqliteTokenize(createSql)
+
+	for i, token := range tokens {
+		if !token.is("INDEX") && !token.is("TRIGGER") {
+			continue
+		}
+
+		name := i + 1
+
+		if name+2 < len(tokens) && tokens[name].is("IF") && tokens[name+1].is("NOT") && tokens[name+2].is("EXISTS") {
+			name += 3
+		}
+
+		if name >= len(tokens) || (name+1 < len(tokens) && tokens[name+1].text == ".") {
+			return createSql
+		}
+
+		return createSql[:tokens[name].start] + quotedSchema + "." + createSql[tokens[name].start:]
+	}
+
+	return createSql
+}
+
+// sqliteToken is a token of a SQLite statement, i.e. a keyword, an
+// identifier, a literal or a punctuation character, with its position
+// and the depth of the parentheses it is in
+type sqliteToken struct {
+	text  string
+	start int
+	end   int
+	depth int
+}
+
+// is checks the token is the keyword
+func (token sqliteToken) is(keyword string) bool {
+	return strings.EqualFold(token.text, keyword)
+}
+
+// sqliteTokenize splits a SQLite statement into tokens, skipping the spaces
+// and the comments. The parentheses have the depth of the text around them
+func sqliteTokenize(sql string) []sqliteToken {
+	tokens := []sqliteToken{}
+	depth := 0
+	i := 0
+
+	isWord := func(c byte) bool {
+		return c == '_' || c == '$' || c >= 0x80 || (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+	}
+
+	for i < len(sql) {
+		c := sql[i]
+		start := i
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n' || c == '\f':
+			i++
+			continue
+		case strings.HasPrefix(sql[i:], "--"):
+			end := strings.IndexByte(sql[i:], '\n')
+			i = lo.Ternary(end == -1, len(sql), i+end+1)
+			continue
+		case strings.HasPrefix(sql[i:], "/*"):
+			end := strings.Index(sql[i+2:], "*/")
+			i = lo.Ternary(end == -1, len(sql), i+2+end+2)
+			continue
+		case c == '\'' || c == '"' || c == '`' || c == '[':
+			closing := lo.Ternary(c == '[', byte(']'), c)
+			i++
+
+			for i < len(sql) {
+				if sql[i] != closing {
+					i++
+					continue
+				}
+
+				// The quotes are escaped by doubling them
+				if closing != ']' && i+1 < len(sql) && sql[i+1] == closing {
+					i += 2
+					continue
+				}
+
+				break
+			}
+
+			i = min(i+1, len(sql))
+		case isWord(c):
+			for i < len(sql) && isWord(sql[i]) {
+				i++
+			}
+		default:
+			i++
+		}
+
+		text := sql[start:i]
+
+		if text == ")" {
+			depth--
+		}
+
+		tokens = append(tokens, sqliteToken{text: text, start: start, end: i, depth: depth})
+
+		if text == "(" {
+			depth++
+		}
+	}
+
+	return tokens
+}
+
+// sqliteUnquote returns the identifier without its quotes
+func sqliteUnquote(identifier string) string {
+	if len(identifier) < 2 {
+		return identifier
+	}
+
+	switch identifier[0] {
+	case '"', '`', '\'':
+		quote := identifier[:1]
+		return strings.ReplaceAll(identifier[1:len(identifier)-1], quote+quote, quote)
+	case '[':
+		return identifier[1 : len(identifier)-1]
+	}
+
+	return identifier
+}
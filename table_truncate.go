@@ -0,0 +1,56 @@
+package sb
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gouniverse/base/database"
+)
+
+// TableTruncateSql returns the SQL removing all the rows from the table,
+// and resetting its auto increment counters
+func TableTruncateSql(ctx database.QueryableContext, tableName string) (sqlString string, err error) {
+	defer recoverError(&err)
+
+	if ctx.Queryable() == nil {
+		return "", errors.New("queryable cannot be nil")
+	}
+
+	databaseType := database.DatabaseType(ctx.Queryable())
+
+	options := TruncateOptions{RestartIdentity: true}
+
+	// The SQLite counters are kept in sqlite_sequence, which
+	// does not exist until a table with AUTOINCREMENT is created
+	if databaseType == DIALECT_SQLITE {
+		master := "sqlite_master"
+		if schema, _, found := strings.Cut(tableName, "."); found {
+			master = schema + "." + master
+		}
+
+		var count int
+		err = ctx.Queryable().QueryRowContext(ctx, `SELECT COUNT(*) FROM `+NewBuilder(DIALECT_SQLITE).quoteTable(master)+` WHERE "type" = 'table' AND "name" = 'sqlite_sequence'`).Scan(&count)
+
+		if err != nil {
+			return "", err
+		}
+
+		options.RestartIdentity = count > 0
+	}
+
+	return NewBuilder(databaseType).Table(tableName).Truncate(options), nil
+}
+
+// TableTruncate removes all the rows from the table,
+// and resets its auto increment counters
+func TableTruncate(ctx database.QueryableContext, tableName string) error {
+	sqlTableTruncate, err := TableTruncateSql(ctx, tableName)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = ctx.Queryable().ExecContext(ctx, sqlTableTruncate)
+
+	return err
+}
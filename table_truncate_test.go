@@ -0,0 +1,158 @@
+package sb
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gouniverse/base/database"
+)
+
+func TestTableTruncateSQLite(t *testing.T) {
+	columns := []Column{
+		{Name: "id", Type: COLUMN_TYPE_INTEGER, PrimaryKey: true, AutoIncrement: true},
+		{Name: "name", Type: COLUMN_TYPE_STRING},
+	}
+
+	db, err := initSQLiteWithTable("test_table_truncate", columns)
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	defer db.Close()
+
+	ctx := database.Context(context.Background(), db)
+
+	for _, name := range []string{"one", "two"} {
+		_, err = db.Exec(NewBuilder(DIALECT_SQLITE).Table("test_table_truncate").Insert(map[string]string{"name": name}))
+		if err != nil {
+			t.Fatal("Error must be NIL but got: ", err.Error())
+		}
+	}
+
+	err = TableTruncate(ctx, "test_table_truncate")
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	_, err = db.Exec(NewBuilder(DIALECT_SQLITE).Table("test_table_truncate").Insert(map[string]string{"name": "three"}))
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	var count, id int
+	err = db.QueryRow(`SELECT COUNT(*), MAX("id") FROM "test_table_truncate"`).Scan(&count, &id)
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	if count != 1 || id != 1 {
+		t.Fatal("Expected 1 row with id 1 but got: ", count, " rows with id ", id)
+	}
+}
+
+func TestTableTruncateSQLiteSchema(t *testing.T) {
+	columns := []Column{
+		{Name: "id", Type: COLUMN_TYPE_INTEGER, PrimaryKey: true, AutoIncrement: true},
+		{Name: "name", Type: COLUMN_TYPE_STRING},
+	}
+
+	db, err := initSQLiteWithTable("test_table_truncate", columns)
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	defer db.Close()
+
+	ctx := database.Context(context.Background(), db)
+
+	for _, name := range []string{"one", "two"} {
+		_, err = db.Exec(NewBuilder(DIALECT_SQLITE).Table("main.test_table_truncate").Insert(map[string]string{"name": name}))
+		if err != nil {
+			t.Fatal("Error must be NIL but got: ", err.Error())
+		}
+	}
+
+	err = TableTruncate(ctx, "main.test_table_truncate")
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	_, err = db.Exec(NewBuilder(DIALECT_SQLITE).Table("main.test_table_truncate").Insert(map[string]string{"name": "three"}))
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	var count, id int
+	err = db.QueryRow(`SELECT COUNT(*), MAX("id") FROM "main"."test_table_truncate"`).Scan(&count, &id)
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	if count != 1 || id != 1 {
+		t.Fatal("Expected 1 row with id 1 but got: ", count, " rows with id ", id)
+	}
+}
+
+func TestTableTruncateSQLiteWithoutSequence(t *testing.T) {
+	columns := []Column{
+		{Name: "name", Type: COLUMN_TYPE_STRING},
+	}
+
+	db, err := initSQLiteWithTable("test_table_truncate", columns)
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	defer db.Close()
+
+	ctx := database.Context(context.Background(), db)
+
+	sql, err := TableTruncateSql(ctx, "test_table_truncate")
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	expected := `DELETE FROM "test_table_truncate";`
+	if sql != expected {
+		t.Fatal("Expected:\n", expected, "\nbut found:\n", sql)
+	}
+
+	err = TableTruncate(ctx, "test_table_truncate")
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+}
+
+func TestTableTruncateSqlNoTable(t *testing.T) {
+	db, err := initSQLiteWithTable("test_table_truncate", []Column{{Name: "name", Type: COLUMN_TYPE_STRING}})
+
+	if err != nil {
+		t.Fatal("Error must be NIL but got: ", err.Error())
+	}
+
+	defer db.Close()
+
+	ctx := database.Context(context.Background(), db)
+
+	_, err = TableTruncateSql(ctx, "")
+
+	if !errors.Is(err, ErrNoTable) {
+		t.Fatal("Expected ErrNoTable but found: ", err)
+	}
+
+	err = TableTruncate(ctx, "")
+
+	if !errors.Is(err, ErrNoTable) {
+		t.Fatal("Expected ErrNoTable but found: ", err)
+	}
+}